@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// sweeperNamePrefix is the name prefix of resources created by acceptance
+// tests, which the sweepers delete.
+const sweeperNamePrefix = "tf-acc-test"
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// sharedClientForRegion returns a client configured from the environment for
+// use by the sweepers.
+func sharedClientForRegion(region string) (*AWSClient, error) {
+	config := &Config{
+		MaxRetries: 5,
+		Profile:    os.Getenv("AWS_PROFILE"),
+		Region:     region,
+	}
+
+	client, err := config.Client(context.Background())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(*AWSClient), nil
+}
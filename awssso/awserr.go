@@ -1,6 +1,9 @@
 package aws
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -35,3 +38,32 @@ func RetryOnAwsCodes(codes []string, f func() (interface{}, error)) (interface{}
 
 	return resp, err
 }
+
+// awsRequestError wraps a failed AWS request, presenting only its error code
+// and message to users while remaining unwrappable for error code checks.
+type awsRequestError struct {
+	err awserr.RequestFailure
+}
+
+func (e *awsRequestError) Error() string {
+	return fmt.Sprintf("%s: %s", e.err.Code(), e.err.Message())
+}
+
+func (e *awsRequestError) Unwrap() error {
+	return e.err
+}
+
+// cleanAwsRequestError logs the request ID, status code and error code of a
+// failed AWS request at DEBUG and returns the error with a concise message.
+// Errors that are not request failures are returned unchanged.
+func cleanAwsRequestError(err error) error {
+	var requestFailure awserr.RequestFailure
+
+	if !errors.As(err, &requestFailure) {
+		return err
+	}
+
+	log.Printf("[DEBUG] AWS request failed (RequestID: %q, StatusCode: %d, Code: %q): %s", requestFailure.RequestID(), requestFailure.StatusCode(), requestFailure.Code(), requestFailure.Message())
+
+	return &awsRequestError{err: requestFailure}
+}
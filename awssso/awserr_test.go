@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+)
+
+func TestCleanAwsRequestError(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	requestFailure := awserr.NewRequestFailure(awserr.New(ssoadmin.ErrCodeConflictException, "permission set is being provisioned", nil), 409, "11111111-2222-3333-4444-555555555555")
+
+	err := cleanAwsRequestError(requestFailure)
+
+	if got, expected := err.Error(), "ConflictException: permission set is being provisioned"; got != expected {
+		t.Errorf("got error %q, expected %q", got, expected)
+	}
+
+	if !tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeConflictException) {
+		t.Errorf("expected error to match error code %s", ssoadmin.ErrCodeConflictException)
+	}
+
+	if !strings.Contains(buf.String(), "11111111-2222-3333-4444-555555555555") {
+		t.Errorf("expected request ID in log output, got: %s", buf.String())
+	}
+}
+
+func TestCleanAwsRequestError_notRequestFailure(t *testing.T) {
+	expected := errors.New("empty output")
+
+	if got := cleanAwsRequestError(expected); got != expected {
+		t.Errorf("got error %v, expected %v", got, expected)
+	}
+}
@@ -1,16 +1,26 @@
 package aws
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
-	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/aws/aws-sdk-go/service/sts"
 	awsbase "github.com/hashicorp/aws-sdk-go-base"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+	"github.com/takescoop/terraform-provider-awssso/version"
 )
 
 type Config struct {
@@ -21,6 +31,21 @@ type Config struct {
 	Token         string
 	Region        string
 	MaxRetries    int
+	RetryMode     string
+	HTTPProxy     string
+
+	CustomCABundle string
+
+	SharedConfigFiles      []string
+	SharedCredentialsFiles []string
+
+	EC2MetadataServiceEndpoint     string
+	EC2MetadataServiceEndpointMode string
+
+	STSRegionalEndpoint string
+
+	UseDualStackEndpoint bool
+	UseFIPSEndpoint      bool
 
 	AssumeRoleARN               string
 	AssumeRoleDurationSeconds   int
@@ -28,36 +53,223 @@ type Config struct {
 	AssumeRolePolicy            string
 	AssumeRolePolicyARNs        []string
 	AssumeRoleSessionName       string
+	AssumeRoleSourceIdentity    string
 	AssumeRoleTags              map[string]string
 	AssumeRoleTransitiveTagKeys []string
 
+	AssumeRoleChain []AssumeRoleConfig
+
+	AssumeRoleWithWebIdentity *AssumeRoleWithWebIdentity
+
+	SSOAdminRoleARN string
+
+	SkipAutoProvision bool
+
 	AllowedAccountIds   []string
 	ForbiddenAccountIds []string
 
-	DefaultTagsConfig *keyvaluetags.DefaultConfig
-	Endpoints         map[string]string
-	IgnoreTagsConfig  *keyvaluetags.IgnoreConfig
-	Insecure          bool
+	DefaultSessionDuration string
+	DefaultTagsConfig      *keyvaluetags.DefaultConfig
+	Endpoints              map[string]string
+	IgnoreTagsConfig       *keyvaluetags.IgnoreConfig
+	Insecure               bool
 
 	SkipCredsValidation     bool
-	SkipGetEC2Platforms     bool
 	SkipRegionValidation    bool
+	AdditionalRegions       []string
 	SkipRequestingAccountId bool
 	SkipMetadataApiCheck    bool
 	S3ForcePathStyle        bool
 
+	// UserAgentExtra is appended to the User-Agent of all API requests.
+	UserAgentExtra string
+
 	terraformVersion string
+
+	clientOnce sync.Once
+	client     *AWSClient
+	clientErr  error
+}
+
+// STS bounds of the assume role session duration. Durations over an hour must
+// also be within the maximum session duration of the role, which is only
+// checked by STS.
+const (
+	assumeRoleMinDurationSeconds = 900
+	assumeRoleMaxDurationSeconds = 43200
+)
+
+// AssumeRoleConfig configures a role to assume as one step of an assume role chain.
+type AssumeRoleConfig struct {
+	RoleARN           string
+	DurationSeconds   int
+	ExternalID        string
+	Policy            string
+	PolicyARNs        []string
+	SessionName       string
+	SourceIdentity    string
+	Tags              map[string]string
+	TransitiveTagKeys []string
+}
+
+// AssumeRoleWithWebIdentity configures assuming a role using an OAuth 2.0 or
+// OpenID Connect token, such as from GitHub Actions or EKS service accounts.
+// Exactly one of WebIdentityToken and WebIdentityTokenFile is set.
+type AssumeRoleWithWebIdentity struct {
+	RoleARN              string
+	SessionName          string
+	WebIdentityToken     string
+	WebIdentityTokenFile string
+}
+
+// assumeRoleChain returns the roles to assume in order, each using the
+// credentials of the previous. The single AssumeRoleARN settings map to a
+// one-element chain.
+func (c *Config) assumeRoleChain() ([]AssumeRoleConfig, error) {
+	if c.AssumeRoleARN == "" {
+		return c.AssumeRoleChain, nil
+	}
+
+	if len(c.AssumeRoleChain) > 0 {
+		return nil, errors.New("AssumeRoleARN and AssumeRoleChain cannot both be configured, use only one")
+	}
+
+	return []AssumeRoleConfig{
+		{
+			RoleARN:           c.AssumeRoleARN,
+			DurationSeconds:   c.AssumeRoleDurationSeconds,
+			ExternalID:        c.AssumeRoleExternalID,
+			Policy:            c.AssumeRolePolicy,
+			PolicyARNs:        c.AssumeRolePolicyARNs,
+			SessionName:       c.AssumeRoleSessionName,
+			SourceIdentity:    c.AssumeRoleSourceIdentity,
+			Tags:              c.AssumeRoleTags,
+			TransitiveTagKeys: c.AssumeRoleTransitiveTagKeys,
+		},
+	}, nil
+}
+
+// Validate checks the configuration for conflicting settings, malformed role
+// ARNs and unknown enum values, without making any requests, so that
+// misconfiguration is reported before credentials are resolved.
+func (c *Config) Validate() error {
+	var errs *multierror.Error
+
+	if c.AccessKey != "" && c.SecretKey == "" {
+		errs = multierror.Append(errs, errors.New("access_key requires secret_key"))
+	}
+
+	if c.SecretKey != "" && c.AccessKey == "" {
+		errs = multierror.Append(errs, errors.New("secret_key requires access_key"))
+	}
+
+	assumeRoleChain, err := c.assumeRoleChain()
+
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	for i, role := range assumeRoleChain {
+		if _, err := arn.Parse(role.RoleARN); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("assume role %d: invalid role ARN (%s): %w", i, role.RoleARN, err))
+		}
+
+		if role.DurationSeconds != 0 && (role.DurationSeconds < assumeRoleMinDurationSeconds || role.DurationSeconds > assumeRoleMaxDurationSeconds) {
+			errs = multierror.Append(errs, fmt.Errorf("assume role %d: duration (%d seconds) must be between %d and %d seconds", i, role.DurationSeconds, assumeRoleMinDurationSeconds, assumeRoleMaxDurationSeconds))
+		}
+	}
+
+	if webIdentity := c.AssumeRoleWithWebIdentity; webIdentity != nil {
+		if c.AccessKey != "" {
+			errs = multierror.Append(errs, errors.New("access_key and assume_role_with_web_identity cannot both be configured, use only one"))
+		}
+
+		if len(assumeRoleChain) > 0 {
+			errs = multierror.Append(errs, errors.New("assume_role and assume_role_with_web_identity cannot both be configured, use only one"))
+		}
+
+		if _, err := arn.Parse(webIdentity.RoleARN); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("assume role with web identity: invalid role ARN (%s): %w", webIdentity.RoleARN, err))
+		}
+
+		if (webIdentity.WebIdentityToken == "") == (webIdentity.WebIdentityTokenFile == "") {
+			errs = multierror.Append(errs, errors.New("assume role with web identity: exactly one of web_identity_token and web_identity_token_file must be configured"))
+		}
+	}
+
+	if c.SSOAdminRoleARN != "" {
+		if _, err := arn.Parse(c.SSOAdminRoleARN); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("invalid SSO Admin role ARN (%s): %w", c.SSOAdminRoleARN, err))
+		}
+	}
+
+	if len(c.AllowedAccountIds) > 0 && len(c.ForbiddenAccountIds) > 0 {
+		errs = multierror.Append(errs, errors.New("allowed_account_ids and forbidden_account_ids cannot both be configured, use only one"))
+	}
+
+	if c.RetryMode != "" && c.RetryMode != retryModeAdaptive && c.RetryMode != retryModeStandard {
+		errs = multierror.Append(errs, fmt.Errorf("unsupported retry mode (%s), expected %s or %s", c.RetryMode, retryModeAdaptive, retryModeStandard))
+	}
+
+	if _, err := c.stsRegionalEndpoint(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if c.EC2MetadataServiceEndpointMode != "" {
+		var mode endpoints.EC2IMDSEndpointModeState
+
+		if err := mode.SetFromString(c.EC2MetadataServiceEndpointMode); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("error setting EC2 metadata service endpoint mode: %w", err))
+		}
+	}
+
+	return errs.ErrorOrNil()
 }
 
 type AWSClient struct {
-	accountid         string
-	DefaultTagsConfig *keyvaluetags.DefaultConfig
-	dnsSuffix         string
-	iamconn           *iam.IAM
-	IgnoreTagsConfig  *keyvaluetags.IgnoreConfig
-	partition         string
-	region            string
-	terraformVersion  string
+	accountid              string
+	allowedAccountIds      []string
+	forbiddenAccountIds    []string
+	DefaultSessionDuration string
+	DefaultTagsConfig      *keyvaluetags.DefaultConfig
+	dnsSuffix              string
+	iamconn                *iam.IAM
+	identitystoreconn      *identitystore.IdentityStore
+	IgnoreTagsConfig       *keyvaluetags.IgnoreConfig
+	partition              string
+	provisioner            permissionSetProvisioner
+	region                 string
+	skipAutoProvision      bool
+	ssoadminconn           *ssoadmin.SSOAdmin
+	stsconn                *sts.STS
+	terraformVersion       string
+}
+
+// AccountID returns the AWS account ID of the provider credentials.
+func (client *AWSClient) AccountID() string {
+	return client.accountid
+}
+
+// ValidateTargetAccountID checks that an account may be the target of resources
+// such as account assignments, per the provider allowed and forbidden account IDs.
+func (client *AWSClient) ValidateTargetAccountID(accountID string) error {
+	return awsbase.ValidateAccountID(accountID, client.allowedAccountIds, client.forbiddenAccountIds)
+}
+
+// Region returns the AWS region the provider is configured for.
+func (client *AWSClient) Region() string {
+	return client.region
+}
+
+// DNSSuffix returns the DNS suffix of the partition the provider is configured for,
+// e.g. amazonaws.com
+func (client *AWSClient) DNSSuffix() string {
+	return client.dnsSuffix
+}
+
+// Partition returns the AWS partition the provider is configured for, e.g. aws
+func (client *AWSClient) Partition() string {
+	return client.partition
 }
 
 // PartitionHostname returns a hostname with the provider domain suffix for the partition
@@ -74,17 +286,44 @@ func (client *AWSClient) RegionalHostname(prefix string) string {
 	return fmt.Sprintf("%s.%s.%s", prefix, client.region, client.dnsSuffix)
 }
 
-// Client configures and returns a fully initialized AWSClient
-func (c *Config) Client() (interface{}, error) {
-	// Get the auth and region. This can fail if keys/regions were not
-	// specified and we're attempting to use the environment.
-	if !c.SkipRegionValidation {
-		if err := awsbase.ValidateRegion(c.Region); err != nil {
-			return nil, err
-		}
-	}
+// PermissionSetARN returns the ARN of a permission set in the provider partition
+// e.g. arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111
+func (client *AWSClient) PermissionSetARN(instanceID, permissionSetID string) string {
+	return arn.ARN{
+		Partition: client.partition,
+		Service:   "sso",
+		Resource:  fmt.Sprintf("permissionSet/%s/%s", instanceID, permissionSetID),
+	}.String()
+}
 
-	awsbaseConfig := &awsbase.Config{
+// IdentityStoreConn returns the AWS SSO Identity Store API client.
+func (client *AWSClient) IdentityStoreConn() *identitystore.IdentityStore {
+	return client.identitystoreconn
+}
+
+// ProvisionPermissionSet provisions a permission set to all accounts it is
+// already provisioned to and waits for it, returning the last provisioning
+// status. The provision is shared with any other resources changing the same
+// permission set at the same time.
+func (client *AWSClient) ProvisionPermissionSet(ctx context.Context, instanceArn, permissionSetArn string) (*ssoadmin.PermissionSetProvisioningStatus, error) {
+	return client.provisioner.provision(ctx, client.ssoadminconn, instanceArn, permissionSetArn)
+}
+
+// SSOAdminConn returns the AWS SSO Admin API client.
+func (client *AWSClient) SSOAdminConn() *ssoadmin.SSOAdmin {
+	return client.ssoadminconn
+}
+
+// STSConn returns the AWS STS API client, using the provider credentials.
+func (client *AWSClient) STSConn() *sts.STS {
+	return client.stsconn
+}
+
+const providerDocumentationURL = "https://registry.terraform.io/providers/takescoop/awssso"
+
+// awsbaseConfig returns the awsbase configuration for the provider settings.
+func (c *Config) awsbaseConfig() *awsbase.Config {
+	return &awsbase.Config{
 		AccessKey:                   c.AccessKey,
 		AssumeRoleARN:               c.AssumeRoleARN,
 		AssumeRoleDurationSeconds:   c.AssumeRoleDurationSeconds,
@@ -94,8 +333,8 @@ func (c *Config) Client() (interface{}, error) {
 		AssumeRoleSessionName:       c.AssumeRoleSessionName,
 		AssumeRoleTags:              c.AssumeRoleTags,
 		AssumeRoleTransitiveTagKeys: c.AssumeRoleTransitiveTagKeys,
-		CallerDocumentationURL:      "https://registry.terraform.io/providers/hashicorp/aws",
-		CallerName:                  "Terraform AWS Provider",
+		CallerDocumentationURL:      providerDocumentationURL,
+		CallerName:                  "Terraform AWS SSO Provider",
 		CredsFilename:               c.CredsFilename,
 		DebugLogging:                logging.IsDebugOrHigher(),
 		IamEndpoint:                 c.Endpoints["iam"],
@@ -110,16 +349,170 @@ func (c *Config) Client() (interface{}, error) {
 		StsEndpoint:                 c.Endpoints["sts"],
 		Token:                       c.Token,
 		UserAgentProducts: []*awsbase.UserAgentProduct{
-			{Name: "APN", Version: "1.0"},
-			{Name: "HashiCorp", Version: "1.0"},
 			{Name: "Terraform", Version: c.terraformVersion,
 				Extra: []string{"+https://www.terraform.io"}},
+			{Name: "terraform-provider-awssso", Version: version.ProviderVersion,
+				Extra: []string{"+" + providerDocumentationURL}},
 		},
 	}
+}
+
+// validateRegion checks that the region is known to the SDK or is one of
+// the additional regions, such as a region launched after the SDK release.
+func (c *Config) validateRegion() error {
+	if c.Region == "" {
+		return errors.New("region must be configured")
+	}
+
+	for _, region := range c.AdditionalRegions {
+		if c.Region == region {
+			return nil
+		}
+	}
+
+	return awsbase.ValidateRegion(c.Region)
+}
+
+var sessionNameTemplateVariable = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// partitionDefaultRegions are the regions used when no region is configured,
+// keyed by the partition of the configured role ARNs.
+var partitionDefaultRegions = map[string]string{
+	endpoints.AwsCnPartitionID:    endpoints.CnNorth1RegionID,
+	endpoints.AwsUsGovPartitionID: endpoints.UsGovWest1RegionID,
+}
+
+// rolePartition returns the partition of the first valid configured role ARN,
+// or an empty string if no role is configured.
+func (c *Config) rolePartition() string {
+	roleARNs := []string{c.AssumeRoleARN, c.SSOAdminRoleARN}
+
+	for _, assumeRole := range c.AssumeRoleChain {
+		roleARNs = append(roleARNs, assumeRole.RoleARN)
+	}
+
+	if c.AssumeRoleWithWebIdentity != nil {
+		roleARNs = append(roleARNs, c.AssumeRoleWithWebIdentity.RoleARN)
+	}
+
+	for _, roleARN := range roleARNs {
+		if v, err := arn.Parse(roleARN); err == nil {
+			return v.Partition
+		}
+	}
+
+	return ""
+}
+
+// setDefaultRegion sets a default region for the GovCloud and China partitions
+// when region validation is skipped and no region is configured, as the
+// session otherwise fails without a region.
+func (c *Config) setDefaultRegion() {
+	if c.Region != "" || !c.SkipRegionValidation {
+		return
+	}
+
+	partition := c.rolePartition()
+	region, ok := partitionDefaultRegions[partition]
+
+	if !ok {
+		return
+	}
+
+	log.Printf("[INFO] No region configured, defaulting to %s for the %s partition", region, partition)
+	c.Region = region
+}
+
+// expandSessionNames expands ${partition} and ${region} in assume role session
+// names. The account ID is not available, as it depends on the assumed role.
+func (c *Config) expandSessionNames() error {
+	partition := endpoints.AwsPartitionID
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), c.Region); ok {
+		partition = p.ID()
+	}
+
+	variables := map[string]string{
+		"partition": partition,
+		"region":    c.Region,
+	}
+
+	expand := func(sessionName string) (string, error) {
+		var unknown []string
+
+		expanded := sessionNameTemplateVariable.ReplaceAllStringFunc(sessionName, func(match string) string {
+			name := sessionNameTemplateVariable.FindStringSubmatch(match)[1]
+
+			if v, ok := variables[name]; ok {
+				return v
+			}
+
+			unknown = append(unknown, name)
+			return match
+		})
+
+		if len(unknown) > 0 {
+			return "", fmt.Errorf("unsupported variables in assume role session name (%s): %s", sessionName, strings.Join(unknown, ", "))
+		}
+
+		return expanded, nil
+	}
+
+	sessionName, err := expand(c.AssumeRoleSessionName)
 
-	sess, accountID, partition, err := awsbase.GetSessionWithAccountIDAndPartition(awsbaseConfig)
 	if err != nil {
-		return nil, fmt.Errorf("error configuring Terraform AWS Provider: %w", err)
+		return err
+	}
+
+	c.AssumeRoleSessionName = sessionName
+
+	for i, role := range c.AssumeRoleChain {
+		sessionName, err := expand(role.SessionName)
+
+		if err != nil {
+			return err
+		}
+
+		c.AssumeRoleChain[i].SessionName = sessionName
+	}
+
+	return nil
+}
+
+// Client configures and returns a fully initialized AWSClient, resolving
+// credentials with ctx. The client is only configured once, and later calls
+// return the same client.
+func (c *Config) Client(ctx context.Context) (interface{}, error) {
+	c.clientOnce.Do(func() {
+		c.client, c.clientErr = c.newClient(ctx)
+	})
+
+	if c.clientErr != nil {
+		return nil, c.clientErr
+	}
+
+	return c.client, nil
+}
+
+func (c *Config) newClient(ctx context.Context) (*AWSClient, error) {
+	c.setDefaultRegion()
+
+	// Get the auth and region. This can fail if keys/regions were not
+	// specified and we're attempting to use the environment.
+	if !c.SkipRegionValidation {
+		if err := c.validateRegion(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.expandSessionNames(); err != nil {
+		return nil, err
+	}
+
+	awsbaseConfig := c.awsbaseConfig()
+
+	sess, accountID, partition, err := c.getSessionWithAccountIDAndPartition(ctx, awsbaseConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring Terraform AWS SSO Provider: %w", err)
 	}
 
 	if accountID == "" {
@@ -135,15 +528,37 @@ func (c *Config) Client() (interface{}, error) {
 		dnsSuffix = p.DNSSuffix()
 	}
 
+	// Only the SSO Admin client uses the SSO Admin role, as the other services
+	// are called in the account of the provider credentials.
+	ssoadminSess := sess
+
+	if c.SSOAdminRoleARN != "" {
+		creds, err := c.assumeRoleCredentials(ctx, awsbaseConfig, AssumeRoleConfig{RoleARN: c.SSOAdminRoleARN}, sess.Config.Credentials)
+
+		if err != nil {
+			return nil, fmt.Errorf("error configuring SSO Admin credentials: %w", err)
+		}
+
+		ssoadminSess = sess.Copy(&aws.Config{Credentials: creds})
+	}
+
 	client := &AWSClient{
-		accountid:         accountID,
-		DefaultTagsConfig: c.DefaultTagsConfig,
-		dnsSuffix:         dnsSuffix,
-		iamconn:           iam.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints["iam"])})),
-		IgnoreTagsConfig:  c.IgnoreTagsConfig,
-		partition:         partition,
-		region:            c.Region,
-		terraformVersion:  c.terraformVersion,
+		accountid:              accountID,
+		allowedAccountIds:      c.AllowedAccountIds,
+		forbiddenAccountIds:    c.ForbiddenAccountIds,
+		DefaultSessionDuration: c.DefaultSessionDuration,
+		DefaultTagsConfig:      c.DefaultTagsConfig,
+		dnsSuffix:              dnsSuffix,
+		iamconn:                iam.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints["iam"])})),
+		identitystoreconn:      identitystore.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints["identitystore"])})),
+		IgnoreTagsConfig:       c.IgnoreTagsConfig,
+		partition:              partition,
+		provisioner:            permissionSetProvisioner{delay: permissionSetProvisionDelay},
+		region:                 c.Region,
+		skipAutoProvision:      c.SkipAutoProvision,
+		ssoadminconn:           ssoadmin.New(ssoadminSess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints["ssoadmin"])})),
+		stsconn:                sts.New(sess.Copy(&aws.Config{Endpoint: aws.String(c.Endpoints["sts"])})),
+		terraformVersion:       c.terraformVersion,
 	}
 
 	// "Global" services that require customizations
@@ -184,31 +599,3 @@ func (c *Config) Client() (interface{}, error) {
 
 	return client, nil
 }
-
-func GetSupportedEC2Platforms(conn *ec2.EC2) ([]string, error) {
-	attrName := "supported-platforms"
-
-	input := ec2.DescribeAccountAttributesInput{
-		AttributeNames: []*string{aws.String(attrName)},
-	}
-	attributes, err := conn.DescribeAccountAttributes(&input)
-	if err != nil {
-		return nil, err
-	}
-
-	var platforms []string
-	for _, attr := range attributes.AccountAttributes {
-		if *attr.AttributeName == attrName {
-			for _, v := range attr.AttributeValues {
-				platforms = append(platforms, *v.AttributeValue)
-			}
-			break
-		}
-	}
-
-	if len(platforms) == 0 {
-		return nil, fmt.Errorf("no EC2 platforms detected")
-	}
-
-	return platforms, nil
-}
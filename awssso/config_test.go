@@ -0,0 +1,1537 @@
+package aws
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/aws/aws-sdk-go/service/sts"
+	awsbase "github.com/hashicorp/aws-sdk-go-base"
+	"github.com/takescoop/terraform-provider-awssso/version"
+)
+
+func testConfig() *Config {
+	return &Config{
+		AccessKey:               "StaticAccessKey",
+		SecretKey:               "StaticSecretKey",
+		Region:                  "us-east-1",
+		MaxRetries:              0,
+		Endpoints:               make(map[string]string),
+		SkipCredsValidation:     true,
+		SkipMetadataApiCheck:    true,
+		SkipRequestingAccountId: true,
+		terraformVersion:        "0.0.0-test",
+	}
+}
+
+func testConfigClient(t *testing.T, config *Config) *AWSClient {
+	t.Helper()
+
+	raw, err := config.Client(context.Background())
+
+	if err != nil {
+		t.Fatalf("error configuring client: %s", err)
+	}
+
+	client, ok := raw.(*AWSClient)
+
+	if !ok {
+		t.Fatalf("expected *AWSClient, got: %T", raw)
+	}
+
+	return client
+}
+
+func TestConfigClient_SSOAdminEndpoint(t *testing.T) {
+	testCases := []struct {
+		TestName         string
+		Endpoint         string
+		ExpectedEndpoint string
+	}{
+		{
+			TestName:         "default",
+			ExpectedEndpoint: "https://sso.us-east-1.amazonaws.com",
+		},
+		{
+			TestName:         "custom",
+			Endpoint:         "https://ssoadmin.example.com",
+			ExpectedEndpoint: "https://ssoadmin.example.com",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+
+			if testCase.Endpoint != "" {
+				config.Endpoints["ssoadmin"] = testCase.Endpoint
+			}
+
+			client := testConfigClient(t, config)
+
+			if client.SSOAdminConn() == nil {
+				t.Fatal("expected ssoadmin client, got nil")
+			}
+
+			if got, expected := client.SSOAdminConn().Endpoint, testCase.ExpectedEndpoint; got != expected {
+				t.Errorf("got endpoint %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestConfigClient_IdentityStoreEndpoint(t *testing.T) {
+	testCases := []struct {
+		TestName         string
+		Endpoint         string
+		Region           string
+		ExpectedEndpoint string
+	}{
+		{
+			TestName:         "default",
+			Region:           "us-west-2",
+			ExpectedEndpoint: "https://identitystore.us-west-2.amazonaws.com",
+		},
+		{
+			TestName:         "default GovCloud",
+			Region:           "us-gov-west-1",
+			ExpectedEndpoint: "https://identitystore.us-gov-west-1.amazonaws.com",
+		},
+		{
+			TestName:         "custom",
+			Endpoint:         "https://identitystore.example.com",
+			Region:           "us-west-2",
+			ExpectedEndpoint: "https://identitystore.example.com",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+			config.Region = testCase.Region
+
+			if testCase.Endpoint != "" {
+				config.Endpoints["identitystore"] = testCase.Endpoint
+			}
+
+			client := testConfigClient(t, config)
+
+			if client.IdentityStoreConn() == nil {
+				t.Fatal("expected identitystore client, got nil")
+			}
+
+			if got, expected := client.IdentityStoreConn().Endpoint, testCase.ExpectedEndpoint; got != expected {
+				t.Errorf("got endpoint %s, expected %s", got, expected)
+			}
+
+			if got, expected := client.IdentityStoreConn().SigningRegion, testCase.Region; got != expected {
+				t.Errorf("got signing region %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestConfigClient_RetryMode(t *testing.T) {
+	testCases := []struct {
+		TestName                 string
+		RetryMode                string
+		ExpectedMinThrottleDelay bool
+	}{
+		{
+			TestName:  "standard",
+			RetryMode: retryModeStandard,
+		},
+		{
+			TestName:                 "adaptive",
+			RetryMode:                retryModeAdaptive,
+			ExpectedMinThrottleDelay: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+			config.MaxRetries = 5
+			config.RetryMode = testCase.RetryMode
+
+			conn := testConfigClient(t, config).SSOAdminConn()
+
+			retryer, ok := conn.Retryer.(client.DefaultRetryer)
+
+			if !ok {
+				t.Fatalf("expected client.DefaultRetryer, got: %T", conn.Retryer)
+			}
+
+			if got, expected := retryer.MaxRetries(), 5; got != expected {
+				t.Errorf("got max retries %d, expected %d", got, expected)
+			}
+
+			if got := retryer.MinThrottleDelay == adaptiveRetryMinThrottleDelay; got != testCase.ExpectedMinThrottleDelay {
+				t.Errorf("got min throttle delay %s, expected adaptive delay: %t", retryer.MinThrottleDelay, testCase.ExpectedMinThrottleDelay)
+			}
+		})
+	}
+}
+
+func TestConfigClient_HTTPProxy(t *testing.T) {
+	testCases := []struct {
+		TestName string
+		Insecure bool
+	}{
+		{
+			TestName: "secure",
+		},
+		{
+			TestName: "insecure",
+			Insecure: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+			config.HTTPProxy = "http://proxy.example.com:3128"
+			config.Insecure = testCase.Insecure
+
+			conn := testConfigClient(t, config).SSOAdminConn()
+
+			transport, ok := conn.Config.HTTPClient.Transport.(*http.Transport)
+
+			if !ok {
+				t.Fatalf("expected *http.Transport, got: %T", conn.Config.HTTPClient.Transport)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, conn.Endpoint, nil)
+
+			if err != nil {
+				t.Fatalf("error creating request: %s", err)
+			}
+
+			proxyURL, err := transport.Proxy(req)
+
+			if err != nil {
+				t.Fatalf("error resolving proxy: %s", err)
+			}
+
+			if proxyURL == nil {
+				t.Fatal("expected proxy URL, got nil")
+			}
+
+			if got, expected := proxyURL.String(), "http://proxy.example.com:3128"; got != expected {
+				t.Errorf("got proxy URL %s, expected %s", got, expected)
+			}
+
+			insecure := transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+
+			if insecure != testCase.Insecure {
+				t.Errorf("got InsecureSkipVerify %t, expected %t", insecure, testCase.Insecure)
+			}
+		})
+	}
+}
+
+func TestConfigClient_Memoized(t *testing.T) {
+	config := testConfig()
+
+	first := testConfigClient(t, config)
+	second := testConfigClient(t, config)
+
+	if first != second {
+		t.Errorf("expected repeated Client calls to return the same client, got %p and %p", first, second)
+	}
+}
+
+func TestConfigClient_HTTPProxyInvalid(t *testing.T) {
+	config := testConfig()
+	config.HTTPProxy = "://proxy.example.com"
+
+	if _, err := config.Client(context.Background()); err == nil {
+		t.Fatal("expected error, got no error")
+	}
+}
+
+func TestAWSClientAccountID(t *testing.T) {
+	client := &AWSClient{accountid: "123456789012"}
+
+	if got, expected := client.AccountID(), "123456789012"; got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+}
+
+func TestAWSClientPermissionSetARN(t *testing.T) {
+	testCases := []struct {
+		Partition   string
+		ExpectedARN string
+	}{
+		{
+			Partition:   "aws",
+			ExpectedARN: "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		},
+		{
+			Partition:   "aws-us-gov",
+			ExpectedARN: "arn:aws-us-gov:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Partition, func(t *testing.T) {
+			client := &AWSClient{partition: testCase.Partition}
+
+			if got, expected := client.PermissionSetARN("ssoins-1111111111111111", "ps-1111111111111111"), testCase.ExpectedARN; got != expected {
+				t.Errorf("got %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestConfigClient_AdditionalRegions(t *testing.T) {
+	testCases := []struct {
+		TestName          string
+		AdditionalRegions []string
+		ExpectedError     bool
+	}{
+		{
+			TestName:      "unknown region",
+			ExpectedError: true,
+		},
+		{
+			TestName:          "additional region",
+			AdditionalRegions: []string{"ap-fake-9"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+			config.Region = "ap-fake-9"
+			config.AdditionalRegions = testCase.AdditionalRegions
+
+			_, err := config.Client(context.Background())
+
+			if got := err != nil; got != testCase.ExpectedError {
+				t.Errorf("got error %t, expected %t: %v", got, testCase.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestAWSClientRegion(t *testing.T) {
+	config := testConfig()
+	config.Region = "eu-west-1"
+
+	client := testConfigClient(t, config)
+
+	if got, expected := client.Region(), "eu-west-1"; got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+}
+
+func TestAWSClientPartition(t *testing.T) {
+	testCases := []struct {
+		Region            string
+		ExpectedDNSSuffix string
+		ExpectedPartition string
+	}{
+		{
+			Region:            "us-east-1",
+			ExpectedDNSSuffix: "amazonaws.com",
+			ExpectedPartition: "aws",
+		},
+		{
+			Region:            "us-gov-west-1",
+			ExpectedDNSSuffix: "amazonaws.com",
+			ExpectedPartition: "aws-us-gov",
+		},
+		{
+			Region:            "cn-north-1",
+			ExpectedDNSSuffix: "amazonaws.com.cn",
+			ExpectedPartition: "aws-cn",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Region, func(t *testing.T) {
+			config := testConfig()
+			config.Region = testCase.Region
+
+			client := testConfigClient(t, config)
+
+			if got, expected := client.DNSSuffix(), testCase.ExpectedDNSSuffix; got != expected {
+				t.Errorf("got DNS suffix %s, expected %s", got, expected)
+			}
+
+			if got, expected := client.Partition(), testCase.ExpectedPartition; got != expected {
+				t.Errorf("got partition %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func testCABundle(t *testing.T) (string, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatalf("error generating key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		NotAfter:              time.Now().Add(time.Hour),
+		NotBefore:             time.Now().Add(-time.Hour),
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatalf("error creating certificate: %s", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		t.Fatalf("error parsing certificate: %s", err)
+	}
+
+	return testTempFile(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), cert
+}
+
+func testTempFile(t *testing.T, content []byte) string {
+	t.Helper()
+
+	f, err := ioutil.TempFile("", "terraform-provider-awssso")
+
+	if err != nil {
+		t.Fatalf("error creating temporary file: %s", err)
+	}
+
+	t.Cleanup(func() { os.Remove(f.Name()) })
+
+	if _, err := f.Write(content); err != nil {
+		t.Fatalf("error writing temporary file: %s", err)
+	}
+
+	if err := f.Close(); err != nil {
+		t.Fatalf("error closing temporary file: %s", err)
+	}
+
+	return f.Name()
+}
+
+func TestConfigClient_CustomCABundle(t *testing.T) {
+	testCases := []struct {
+		TestName string
+		Insecure bool
+	}{
+		{
+			TestName: "secure",
+		},
+		{
+			TestName: "insecure",
+			Insecure: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			path, cert := testCABundle(t)
+
+			config := testConfig()
+			config.CustomCABundle = path
+			config.Insecure = testCase.Insecure
+
+			conn := testConfigClient(t, config).SSOAdminConn()
+			transport := conn.Config.HTTPClient.Transport.(*http.Transport)
+
+			if transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil {
+				t.Fatal("expected custom root CAs, got nil")
+			}
+
+			if _, err := cert.Verify(x509.VerifyOptions{Roots: transport.TLSClientConfig.RootCAs}); err != nil {
+				t.Errorf("expected custom CA to be trusted: %s", err)
+			}
+
+			if got := transport.TLSClientConfig.InsecureSkipVerify; got != testCase.Insecure {
+				t.Errorf("got InsecureSkipVerify %t, expected %t", got, testCase.Insecure)
+			}
+		})
+	}
+}
+
+func TestConfigClient_CustomCABundleMalformed(t *testing.T) {
+	config := testConfig()
+	config.CustomCABundle = testTempFile(t, []byte("not a certificate"))
+
+	_, err := config.Client(context.Background())
+
+	if err == nil {
+		t.Fatal("expected error, got no error")
+	}
+
+	if expected := regexp.MustCompile(`no valid PEM certificates found`); !expected.MatchString(err.Error()) {
+		t.Errorf("expected error %s, got: %s", expected.String(), err)
+	}
+}
+
+func TestConfigSessionOptions_SharedConfigFiles(t *testing.T) {
+	testCases := []struct {
+		TestName               string
+		CredsFilename          string
+		SharedConfigFiles      []string
+		SharedCredentialsFiles []string
+		Expected               []string
+	}{
+		{
+			TestName: "none",
+		},
+		{
+			TestName:          "config files",
+			SharedConfigFiles: []string{"/config1", "/config2"},
+			Expected:          []string{"/config1", "/config2", defaults.SharedCredentialsFilename()},
+		},
+		{
+			TestName:          "config files with credentials filename",
+			CredsFilename:     "/credentials",
+			SharedConfigFiles: []string{"/config"},
+			Expected:          []string{"/config", "/credentials"},
+		},
+		{
+			TestName:               "credentials files",
+			SharedCredentialsFiles: []string{"/credentials1", "/credentials2"},
+			Expected:               []string{defaults.SharedConfigFilename(), "/credentials1", "/credentials2"},
+		},
+		{
+			TestName:               "both",
+			SharedConfigFiles:      []string{"/config"},
+			SharedCredentialsFiles: []string{"/credentials"},
+			Expected:               []string{"/config", "/credentials"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+			config.CredsFilename = testCase.CredsFilename
+			config.SharedConfigFiles = testCase.SharedConfigFiles
+			config.SharedCredentialsFiles = testCase.SharedCredentialsFiles
+
+			options, err := config.sessionOptions(context.Background(), config.awsbaseConfig())
+
+			if err != nil {
+				t.Fatalf("error building session options: %s", err)
+			}
+
+			if got := options.SharedConfigFiles; !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got shared config files %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestConfigClient_SharedConfigFilesProfile(t *testing.T) {
+	testUnsetenv(t, "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY")
+
+	config := testConfig()
+	config.AccessKey = ""
+	config.SecretKey = ""
+	config.Profile = "test"
+	config.SharedConfigFiles = []string{testTempFile(t, []byte("[profile test]\naws_access_key_id = ConfigAccessKey\naws_secret_access_key = ConfigSecretKey\n"))}
+	config.SharedCredentialsFiles = []string{testTempFile(t, []byte(""))}
+
+	creds, err := testConfigClient(t, config).SSOAdminConn().Config.Credentials.Get()
+
+	if err != nil {
+		t.Fatalf("error getting credentials: %s", err)
+	}
+
+	if got, expected := creds.AccessKeyID, "ConfigAccessKey"; got != expected {
+		t.Errorf("got access key %s, expected %s", got, expected)
+	}
+}
+
+func TestConfigClient_SSOSessionProfile(t *testing.T) {
+	home, err := ioutil.TempDir("", "terraform-provider-awssso")
+
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %s", err)
+	}
+
+	t.Cleanup(func() { os.RemoveAll(home) })
+
+	// Environment credentials would otherwise take precedence over the profile.
+	testSetenv(t, map[string]string{
+		"AWS_ACCESS_KEY_ID":     "EnvAccessKey",
+		"AWS_SECRET_ACCESS_KEY": "EnvSecretKey",
+		"HOME":                  home,
+	})
+
+	config := testConfig()
+	config.AccessKey = ""
+	config.SecretKey = ""
+	config.Profile = "test"
+	config.SharedConfigFiles = []string{testTempFile(t, []byte(`[profile test]
+sso_session = corp
+sso_account_id = 123456789012
+sso_role_name = Admin
+
+[sso-session corp]
+sso_region = us-east-1
+sso_start_url = https://corp.awsapps.com/start
+`))}
+	config.SharedCredentialsFiles = []string{testTempFile(t, []byte(""))}
+
+	_, err = config.Client(context.Background())
+
+	// Without a cached SSO token, resolving the profile fails in the SSO
+	// credential provider rather than using the environment credentials.
+	if err == nil {
+		t.Fatal("expected error resolving SSO credentials without a cached token, got none")
+	}
+
+	if expected := regexp.MustCompile(`cached SSO token`); !expected.MatchString(err.Error()) {
+		t.Errorf("expected error matching %s, got: %s", expected, err)
+	}
+}
+
+func TestSharedConfigProfileUsesSSO(t *testing.T) {
+	filename := testTempFile(t, []byte(`[default]
+region = us-east-1
+
+[profile keys]
+aws_access_key_id = ConfigAccessKey
+
+[profile session]
+sso_session = corp
+
+[profile legacy]
+sso_start_url = https://corp.awsapps.com/start
+
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+`))
+
+	for profile, expected := range map[string]bool{
+		"default": false,
+		"keys":    false,
+		"session": true,
+		"legacy":  true,
+		"corp":    false,
+		"missing": false,
+	} {
+		got, err := sharedConfigProfileUsesSSO(filename, profile)
+
+		if err != nil {
+			t.Fatalf("error reading shared config file: %s", err)
+		}
+
+		if got != expected {
+			t.Errorf("profile %s: got %t, expected %t", profile, got, expected)
+		}
+	}
+}
+
+// testSetenv sets the given environment variables for the duration of the test.
+func testSetenv(t *testing.T, env map[string]string) {
+	t.Helper()
+
+	for key, value := range env {
+		key := key
+
+		if v, ok := os.LookupEnv(key); ok {
+			t.Cleanup(func() { os.Setenv(key, v) })
+		} else {
+			t.Cleanup(func() { os.Unsetenv(key) })
+		}
+
+		os.Setenv(key, value)
+	}
+}
+
+// testUnsetenv unsets the given environment variables for the duration of the test.
+func testUnsetenv(t *testing.T, keys ...string) {
+	t.Helper()
+
+	for _, key := range keys {
+		key := key
+
+		if v, ok := os.LookupEnv(key); ok {
+			os.Unsetenv(key)
+			t.Cleanup(func() { os.Setenv(key, v) })
+		}
+	}
+}
+
+func TestConfigSession_EC2MetadataServiceEndpoint(t *testing.T) {
+	testCases := []struct {
+		TestName         string
+		Endpoint         string
+		EndpointMode     string
+		ExpectedEndpoint string
+	}{
+		{
+			TestName:         "default",
+			ExpectedEndpoint: "http://169.254.169.254",
+		},
+		{
+			TestName:         "custom endpoint",
+			Endpoint:         "http://metadata.example.com",
+			ExpectedEndpoint: "http://metadata.example.com",
+		},
+		{
+			TestName:         "IPv6 mode",
+			EndpointMode:     ec2MetadataServiceEndpointModeIPv6,
+			ExpectedEndpoint: "http://[fd00:ec2::254]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			testUnsetenv(t, "AWS_EC2_METADATA_SERVICE_ENDPOINT", "AWS_EC2_METADATA_SERVICE_ENDPOINT_MODE")
+
+			config := testConfig()
+			config.EC2MetadataServiceEndpoint = testCase.Endpoint
+			config.EC2MetadataServiceEndpointMode = testCase.EndpointMode
+
+			sess, err := config.getSession(context.Background(), config.awsbaseConfig())
+
+			if err != nil {
+				t.Fatalf("error configuring session: %s", err)
+			}
+
+			if got, expected := ec2metadata.New(sess).Endpoint, testCase.ExpectedEndpoint; got != expected {
+				t.Errorf("got endpoint %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestConfigClient_EC2MetadataServiceEndpointModeInvalid(t *testing.T) {
+	config := testConfig()
+	config.EC2MetadataServiceEndpointMode = "IPv5"
+
+	if _, err := config.Client(context.Background()); err == nil {
+		t.Fatal("expected error, got no error")
+	}
+}
+
+func TestConfigSession_STSRegionalEndpoint(t *testing.T) {
+	testCases := []struct {
+		TestName            string
+		STSRegionalEndpoint string
+		ExpectedEndpoint    string
+	}{
+		{
+			TestName:            "legacy",
+			STSRegionalEndpoint: stsRegionalEndpointLegacy,
+			ExpectedEndpoint:    "https://sts.amazonaws.com",
+		},
+		{
+			TestName:            "regional",
+			STSRegionalEndpoint: stsRegionalEndpointRegional,
+			ExpectedEndpoint:    "https://sts.us-east-1.amazonaws.com",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			testUnsetenv(t, "AWS_STS_REGIONAL_ENDPOINTS")
+
+			config := testConfig()
+			config.STSRegionalEndpoint = testCase.STSRegionalEndpoint
+
+			sess, err := config.getSession(context.Background(), config.awsbaseConfig())
+
+			if err != nil {
+				t.Fatalf("error configuring session: %s", err)
+			}
+
+			if got, expected := sts.New(sess).Endpoint, testCase.ExpectedEndpoint; got != expected {
+				t.Errorf("got endpoint %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestConfigClient_STSEndpoint(t *testing.T) {
+	testCases := []struct {
+		TestName            string
+		Endpoint            string
+		STSRegionalEndpoint string
+		ExpectedEndpoint    string
+	}{
+		{
+			TestName:            "legacy",
+			STSRegionalEndpoint: stsRegionalEndpointLegacy,
+			ExpectedEndpoint:    "https://sts.amazonaws.com",
+		},
+		{
+			TestName:            "regional",
+			STSRegionalEndpoint: stsRegionalEndpointRegional,
+			ExpectedEndpoint:    "https://sts.us-east-1.amazonaws.com",
+		},
+		{
+			TestName:            "custom",
+			Endpoint:            "https://sts.example.com",
+			STSRegionalEndpoint: stsRegionalEndpointRegional,
+			ExpectedEndpoint:    "https://sts.example.com",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			testUnsetenv(t, "AWS_STS_REGIONAL_ENDPOINTS")
+
+			config := testConfig()
+			config.STSRegionalEndpoint = testCase.STSRegionalEndpoint
+
+			if testCase.Endpoint != "" {
+				config.Endpoints["sts"] = testCase.Endpoint
+			}
+
+			client := testConfigClient(t, config)
+
+			if client.STSConn() == nil {
+				t.Fatal("expected sts client, got nil")
+			}
+
+			if got, expected := client.STSConn().Endpoint, testCase.ExpectedEndpoint; got != expected {
+				t.Errorf("got endpoint %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestConfigClient_UserAgent(t *testing.T) {
+	testCases := []struct {
+		TestName       string
+		UserAgentExtra string
+		Expected       []string
+	}{
+		{
+			TestName: "default",
+			Expected: []string{
+				"Terraform/0.0.0-test (+https://www.terraform.io)",
+				"terraform-provider-awssso/" + version.ProviderVersion + " (+https://registry.terraform.io/providers/takescoop/awssso)",
+			},
+		},
+		{
+			TestName:       "extra",
+			UserAgentExtra: "my-pipeline/1.2.0",
+			Expected: []string{
+				"terraform-provider-awssso/" + version.ProviderVersion,
+				"my-pipeline/1.2.0",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			testUnsetenv(t, awsbase.AppendUserAgentEnvVar)
+
+			config := testConfig()
+			config.UserAgentExtra = testCase.UserAgentExtra
+
+			client := testConfigClient(t, config)
+
+			req, _ := client.SSOAdminConn().ListInstancesRequest(&ssoadmin.ListInstancesInput{})
+
+			if err := req.Build(); err != nil {
+				t.Fatalf("error building request: %s", err)
+			}
+
+			userAgent := req.HTTPRequest.Header.Get("User-Agent")
+
+			for _, expected := range testCase.Expected {
+				if !strings.Contains(userAgent, expected) {
+					t.Errorf("expected User-Agent to contain %q, got: %s", expected, userAgent)
+				}
+			}
+
+			if strings.Contains(userAgent, "HashiCorp/") || strings.Contains(userAgent, "APN/") {
+				t.Errorf("expected User-Agent without Terraform AWS Provider products, got: %s", userAgent)
+			}
+		})
+	}
+}
+
+func TestConfigClient_STSRegionalEndpointInvalid(t *testing.T) {
+	config := testConfig()
+	config.STSRegionalEndpoint = "global"
+
+	if _, err := config.Client(context.Background()); err == nil {
+		t.Fatal("expected error, got no error")
+	}
+}
+
+// testMockSTS starts an STS query protocol server that records the parsed
+// form of each request and responds with the XML body returned by respond.
+func testMockSTS(t *testing.T, respond func(r *http.Request) string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("error parsing STS request: %s", err)
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, respond(r))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+// testSTSCredentialsResponse returns an STS response for action containing
+// credentials with the given access key.
+func testSTSCredentialsResponse(action, accessKey string) string {
+	return fmt.Sprintf(`<%[1]sResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <%[1]sResult>
+    <Credentials>
+      <AccessKeyId>%[2]s</AccessKeyId>
+      <SecretAccessKey>SecretKey</SecretAccessKey>
+      <SessionToken>SessionToken</SessionToken>
+      <Expiration>2099-01-01T00:00:00Z</Expiration>
+    </Credentials>
+  </%[1]sResult>
+</%[1]sResponse>`, action, accessKey)
+}
+
+func TestConfigGetCredentials_AssumeRoleWithWebIdentity(t *testing.T) {
+	var requests []url.Values
+
+	stsURL := testMockSTS(t, func(r *http.Request) string {
+		requests = append(requests, r.PostForm)
+
+		return testSTSCredentialsResponse("AssumeRoleWithWebIdentity", "WebIdentityAccessKey")
+	})
+
+	config := testConfig()
+	config.Endpoints["sts"] = stsURL
+	config.AssumeRoleWithWebIdentity = &AssumeRoleWithWebIdentity{
+		RoleARN:              "arn:aws:iam::123456789012:role/WebIdentity",
+		SessionName:          "terraform",
+		WebIdentityTokenFile: testTempFile(t, []byte("token-from-file")),
+	}
+
+	creds, err := config.getCredentials(context.Background(), config.awsbaseConfig())
+
+	if err != nil {
+		t.Fatalf("error getting credentials: %s", err)
+	}
+
+	value, err := creds.Get()
+
+	if err != nil {
+		t.Fatalf("error retrieving credentials: %s", err)
+	}
+
+	if got, expected := value.AccessKeyID, "WebIdentityAccessKey"; got != expected {
+		t.Errorf("got access key %s, expected %s", got, expected)
+	}
+
+	if got, expected := len(requests), 1; got != expected {
+		t.Fatalf("got %d STS requests, expected %d", got, expected)
+	}
+
+	for k, expected := range map[string]string{
+		"Action":           "AssumeRoleWithWebIdentity",
+		"RoleArn":          "arn:aws:iam::123456789012:role/WebIdentity",
+		"RoleSessionName":  "terraform",
+		"WebIdentityToken": "token-from-file",
+	} {
+		if got := requests[0].Get(k); got != expected {
+			t.Errorf("got %s %s, expected %s", k, got, expected)
+		}
+	}
+}
+
+func TestConfigGetCredentials_AssumeRoleWithWebIdentityConflict(t *testing.T) {
+	config := testConfig()
+	config.AssumeRoleARN = "arn:aws:iam::123456789012:role/Admin"
+	config.AssumeRoleWithWebIdentity = &AssumeRoleWithWebIdentity{
+		RoleARN:          "arn:aws:iam::123456789012:role/WebIdentity",
+		WebIdentityToken: "token",
+	}
+
+	_, err := config.getCredentials(context.Background(), config.awsbaseConfig())
+
+	if expected := regexp.MustCompile(`assume_role and assume_role_with_web_identity cannot both be configured`); err == nil || !expected.MatchString(err.Error()) {
+		t.Fatalf("expected error %s, got: %v", expected.String(), err)
+	}
+}
+
+func TestConfigGetCredentials_AssumeRoleChain(t *testing.T) {
+	var roleArns, signingAccessKeys []string
+
+	stsURL := testMockSTS(t, func(r *http.Request) string {
+		roleArns = append(roleArns, r.PostForm.Get("RoleArn"))
+
+		// Authorization: AWS4-HMAC-SHA256 Credential=ACCESS_KEY/DATE/REGION/sts/aws4_request, ...
+		credential := regexp.MustCompile(`Credential=([^/]+)/`).FindStringSubmatch(r.Header.Get("Authorization"))
+		if len(credential) == 2 {
+			signingAccessKeys = append(signingAccessKeys, credential[1])
+		}
+
+		return testSTSCredentialsResponse("AssumeRole", fmt.Sprintf("Role%dAccessKey", len(roleArns)))
+	})
+
+	config := testConfig()
+	config.Endpoints["sts"] = stsURL
+	config.AssumeRoleChain = []AssumeRoleConfig{
+		{RoleARN: "arn:aws:iam::111111111111:role/Provisioning"},
+		{RoleARN: "arn:aws:iam::222222222222:role/SSOAdmin"},
+	}
+
+	creds, err := config.getCredentials(context.Background(), config.awsbaseConfig())
+
+	if err != nil {
+		t.Fatalf("error getting credentials: %s", err)
+	}
+
+	value, err := creds.Get()
+
+	if err != nil {
+		t.Fatalf("error retrieving credentials: %s", err)
+	}
+
+	if got, expected := value.AccessKeyID, "Role2AccessKey"; got != expected {
+		t.Errorf("got access key %s, expected %s", got, expected)
+	}
+
+	if expected := []string{"arn:aws:iam::111111111111:role/Provisioning", "arn:aws:iam::222222222222:role/SSOAdmin"}; !reflect.DeepEqual(roleArns, expected) {
+		t.Errorf("got assumed roles %v, expected %v", roleArns, expected)
+	}
+
+	if expected := []string{"StaticAccessKey", "Role1AccessKey"}; !reflect.DeepEqual(signingAccessKeys, expected) {
+		t.Errorf("got signing access keys %v, expected %v", signingAccessKeys, expected)
+	}
+}
+
+func TestConfigGetCredentials_AssumeRoleHTTPProxy(t *testing.T) {
+	var hosts []string
+
+	proxyURL := testMockSTS(t, func(r *http.Request) string {
+		// Requests sent through a proxy carry the absolute target URL.
+		hosts = append(hosts, r.URL.Host)
+
+		return testSTSCredentialsResponse("AssumeRole", "RoleAccessKey")
+	})
+
+	config := testConfig()
+	config.Endpoints["sts"] = "http://sts.example.com"
+	config.HTTPProxy = proxyURL
+	config.AssumeRoleChain = []AssumeRoleConfig{
+		{RoleARN: "arn:aws:iam::111111111111:role/Provisioning"},
+	}
+
+	creds, err := config.getCredentials(context.Background(), config.awsbaseConfig())
+
+	if err != nil {
+		t.Fatalf("error getting credentials: %s", err)
+	}
+
+	value, err := creds.Get()
+
+	if err != nil {
+		t.Fatalf("error retrieving credentials: %s", err)
+	}
+
+	if got, expected := value.AccessKeyID, "RoleAccessKey"; got != expected {
+		t.Errorf("got access key %s, expected %s", got, expected)
+	}
+
+	if expected := []string{"sts.example.com"}; !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("got proxied hosts %v, expected %v", hosts, expected)
+	}
+}
+
+func TestConfigGetCredentials_AssumeRoleCustomCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		fmt.Fprint(w, testSTSCredentialsResponse("AssumeRole", "RoleAccessKey"))
+	}))
+	t.Cleanup(server.Close)
+
+	bundle := testTempFile(t, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	testCases := []struct {
+		TestName       string
+		CustomCABundle string
+		Insecure       bool
+		ExpectedError  *regexp.Regexp
+	}{
+		{
+			TestName:       "custom CA",
+			CustomCABundle: bundle,
+		},
+		{
+			TestName: "insecure",
+			Insecure: true,
+		},
+		{
+			TestName:      "untrusted",
+			ExpectedError: regexp.MustCompile(`cannot be assumed`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+			config.Endpoints["sts"] = server.URL
+			config.CustomCABundle = testCase.CustomCABundle
+			config.Insecure = testCase.Insecure
+			config.AssumeRoleChain = []AssumeRoleConfig{
+				{RoleARN: "arn:aws:iam::111111111111:role/Provisioning"},
+			}
+
+			creds, err := config.getCredentials(context.Background(), config.awsbaseConfig())
+
+			if err == nil {
+				_, err = creds.Get()
+			}
+
+			if testCase.ExpectedError != nil {
+				if err == nil || !testCase.ExpectedError.MatchString(err.Error()) {
+					t.Fatalf("expected error %s, got: %v", testCase.ExpectedError.String(), err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("error retrieving credentials: %s", err)
+			}
+		})
+	}
+}
+
+func TestConfigClient_AssumeRoleSessionNameTemplate(t *testing.T) {
+	var requests []url.Values
+
+	stsURL := testMockSTS(t, func(r *http.Request) string {
+		requests = append(requests, r.PostForm)
+
+		return testSTSCredentialsResponse("AssumeRole", "RoleAccessKey")
+	})
+
+	config := testConfig()
+	config.Region = "us-gov-west-1"
+	config.Endpoints["sts"] = stsURL
+	config.AssumeRoleARN = "arn:aws-us-gov:iam::123456789012:role/Admin"
+	config.AssumeRoleSessionName = "terraform-${partition}"
+
+	client := testConfigClient(t, config)
+
+	if _, err := client.SSOAdminConn().Config.Credentials.Get(); err != nil {
+		t.Fatalf("error getting credentials: %s", err)
+	}
+
+	if got, expected := len(requests), 1; got != expected {
+		t.Fatalf("got %d STS requests, expected %d", got, expected)
+	}
+
+	if got, expected := requests[0].Get("RoleSessionName"), "terraform-aws-us-gov"; got != expected {
+		t.Errorf("got RoleSessionName %s, expected %s", got, expected)
+	}
+}
+
+func TestConfigClient_AssumeRoleSessionNameTemplateUnknown(t *testing.T) {
+	config := testConfig()
+	config.AssumeRoleARN = "arn:aws:iam::123456789012:role/Admin"
+	config.AssumeRoleSessionName = "terraform-${account_id}"
+
+	_, err := config.Client(context.Background())
+
+	if err == nil {
+		t.Fatal("expected error, got no error")
+	}
+
+	if expected := regexp.MustCompile(`unsupported variables in assume role session name \(terraform-\$\{account_id\}\): account_id`); !expected.MatchString(err.Error()) {
+		t.Fatalf("expected error %s, got: %s", expected.String(), err)
+	}
+}
+
+func TestConfigAssumeRoleChain(t *testing.T) {
+	config := testConfig()
+	config.AssumeRoleARN = "arn:aws:iam::123456789012:role/Admin"
+	config.AssumeRoleSessionName = "terraform"
+
+	chain, err := config.assumeRoleChain()
+
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	if expected := []AssumeRoleConfig{{RoleARN: "arn:aws:iam::123456789012:role/Admin", SessionName: "terraform"}}; !reflect.DeepEqual(chain, expected) {
+		t.Errorf("got chain %v, expected %v", chain, expected)
+	}
+
+	config.AssumeRoleChain = []AssumeRoleConfig{{RoleARN: "arn:aws:iam::123456789012:role/Other"}}
+
+	if _, err := config.assumeRoleChain(); err == nil {
+		t.Fatal("expected error, got no error")
+	}
+}
+
+func TestConfigGetCredentials_AssumeRoleSourceIdentity(t *testing.T) {
+	var requests []url.Values
+
+	stsURL := testMockSTS(t, func(r *http.Request) string {
+		requests = append(requests, r.PostForm)
+
+		return testSTSCredentialsResponse("AssumeRole", "RoleAccessKey")
+	})
+
+	config := testConfig()
+	config.Endpoints["sts"] = stsURL
+	config.AssumeRoleARN = "arn:aws:iam::123456789012:role/Admin"
+	config.AssumeRoleSourceIdentity = "jane@example.com"
+
+	if _, err := config.getCredentials(context.Background(), config.awsbaseConfig()); err != nil {
+		t.Fatalf("error getting credentials: %s", err)
+	}
+
+	if got, expected := len(requests), 1; got != expected {
+		t.Fatalf("got %d STS requests, expected %d", got, expected)
+	}
+
+	if got, expected := requests[0].Get("SourceIdentity"), "jane@example.com"; got != expected {
+		t.Errorf("got SourceIdentity %s, expected %s", got, expected)
+	}
+}
+
+func TestConfigClient_SSOAdminRoleARN(t *testing.T) {
+	var requests []url.Values
+
+	stsURL := testMockSTS(t, func(r *http.Request) string {
+		requests = append(requests, r.PostForm)
+
+		return testSTSCredentialsResponse("AssumeRole", "SSOAdminAccessKey")
+	})
+
+	config := testConfig()
+	config.Endpoints["sts"] = stsURL
+	config.SSOAdminRoleARN = "arn:aws:iam::123456789012:role/SSOAdmin"
+
+	client := testConfigClient(t, config)
+
+	ssoadminCreds, err := client.SSOAdminConn().Config.Credentials.Get()
+
+	if err != nil {
+		t.Fatalf("error getting SSO Admin credentials: %s", err)
+	}
+
+	if got, expected := ssoadminCreds.AccessKeyID, "SSOAdminAccessKey"; got != expected {
+		t.Errorf("got SSO Admin access key %s, expected %s", got, expected)
+	}
+
+	iamCreds, err := client.iamconn.Config.Credentials.Get()
+
+	if err != nil {
+		t.Fatalf("error getting IAM credentials: %s", err)
+	}
+
+	if got, expected := iamCreds.AccessKeyID, "StaticAccessKey"; got != expected {
+		t.Errorf("got IAM access key %s, expected %s", got, expected)
+	}
+
+	if got, expected := len(requests), 1; got != expected {
+		t.Fatalf("got %d STS requests, expected %d", got, expected)
+	}
+
+	if got, expected := requests[0].Get("RoleArn"), "arn:aws:iam::123456789012:role/SSOAdmin"; got != expected {
+		t.Errorf("got RoleArn %s, expected %s", got, expected)
+	}
+}
+
+func TestConfigSession_UseFIPSEndpoint(t *testing.T) {
+	testUnsetenv(t, "AWS_USE_FIPS_ENDPOINT")
+
+	config := testConfig()
+	config.UseFIPSEndpoint = true
+
+	sess, err := config.getSession(context.Background(), config.awsbaseConfig())
+
+	if err != nil {
+		t.Fatalf("error configuring session: %s", err)
+	}
+
+	endpoint, err := url.Parse(sts.New(sess).Endpoint)
+
+	if err != nil {
+		t.Fatalf("error parsing endpoint: %s", err)
+	}
+
+	if !strings.Contains(endpoint.Host, "fips") {
+		t.Errorf("expected FIPS endpoint, got: %s", endpoint.Host)
+	}
+}
+
+func TestConfigClient_UseFIPSEndpointCustomEndpoint(t *testing.T) {
+	testUnsetenv(t, "AWS_USE_FIPS_ENDPOINT")
+
+	config := testConfig()
+	config.UseFIPSEndpoint = true
+	config.Endpoints["ssoadmin"] = "https://ssoadmin.example.com"
+
+	if got, expected := testConfigClient(t, config).SSOAdminConn().Endpoint, "https://ssoadmin.example.com"; got != expected {
+		t.Errorf("got endpoint %s, expected %s", got, expected)
+	}
+}
+
+func TestConfigSession_UseDualStackEndpoint(t *testing.T) {
+	testCases := []struct {
+		TestName                      string
+		UseFIPSEndpoint               bool
+		ExpectedIdentityStoreEndpoint string
+		ExpectedSSOAdminEndpoint      string
+	}{
+		{
+			TestName:                      "dualstack",
+			ExpectedIdentityStoreEndpoint: "https://identitystore.us-east-1.api.aws",
+			ExpectedSSOAdminEndpoint:      "https://sso.us-east-1.api.aws",
+		},
+		{
+			TestName:                      "dualstack and FIPS",
+			UseFIPSEndpoint:               true,
+			ExpectedIdentityStoreEndpoint: "https://identitystore-fips.us-east-1.api.aws",
+			ExpectedSSOAdminEndpoint:      "https://sso-fips.us-east-1.api.aws",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			testUnsetenv(t, "AWS_USE_DUALSTACK_ENDPOINT", "AWS_USE_FIPS_ENDPOINT")
+
+			config := testConfig()
+			config.UseDualStackEndpoint = true
+			config.UseFIPSEndpoint = testCase.UseFIPSEndpoint
+
+			client := testConfigClient(t, config)
+
+			if got, expected := client.IdentityStoreConn().Endpoint, testCase.ExpectedIdentityStoreEndpoint; got != expected {
+				t.Errorf("got identitystore endpoint %s, expected %s", got, expected)
+			}
+
+			if got, expected := client.SSOAdminConn().Endpoint, testCase.ExpectedSSOAdminEndpoint; got != expected {
+				t.Errorf("got ssoadmin endpoint %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Configure     func(*Config)
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName:  "valid",
+			Configure: func(c *Config) {},
+		},
+		{
+			TestName:      "access key without secret key",
+			Configure:     func(c *Config) { c.SecretKey = "" },
+			ExpectedError: regexp.MustCompile(`access_key requires secret_key`),
+		},
+		{
+			TestName:      "secret key without access key",
+			Configure:     func(c *Config) { c.AccessKey = "" },
+			ExpectedError: regexp.MustCompile(`secret_key requires access_key`),
+		},
+		{
+			TestName: "static keys and web identity",
+			Configure: func(c *Config) {
+				c.AssumeRoleWithWebIdentity = &AssumeRoleWithWebIdentity{RoleARN: "arn:aws:iam::123456789012:role/web", WebIdentityToken: "token"}
+			},
+			ExpectedError: regexp.MustCompile(`access_key and assume_role_with_web_identity cannot both be configured`),
+		},
+		{
+			TestName: "assume role and web identity",
+			Configure: func(c *Config) {
+				c.AccessKey = ""
+				c.SecretKey = ""
+				c.AssumeRoleARN = "arn:aws:iam::123456789012:role/test"
+				c.AssumeRoleWithWebIdentity = &AssumeRoleWithWebIdentity{RoleARN: "arn:aws:iam::123456789012:role/web", WebIdentityToken: "token"}
+			},
+			ExpectedError: regexp.MustCompile(`assume_role and assume_role_with_web_identity cannot both be configured`),
+		},
+		{
+			TestName: "web identity token and token file",
+			Configure: func(c *Config) {
+				c.AccessKey = ""
+				c.SecretKey = ""
+				c.AssumeRoleWithWebIdentity = &AssumeRoleWithWebIdentity{RoleARN: "arn:aws:iam::123456789012:role/web", WebIdentityToken: "token", WebIdentityTokenFile: "/token"}
+			},
+			ExpectedError: regexp.MustCompile(`exactly one of web_identity_token and web_identity_token_file`),
+		},
+		{
+			TestName: "assume role ARN and chain",
+			Configure: func(c *Config) {
+				c.AssumeRoleARN = "arn:aws:iam::123456789012:role/test"
+				c.AssumeRoleChain = []AssumeRoleConfig{{RoleARN: "arn:aws:iam::123456789012:role/chain"}}
+			},
+			ExpectedError: regexp.MustCompile(`AssumeRoleARN and AssumeRoleChain cannot both be configured`),
+		},
+		{
+			TestName:      "malformed assume role ARN",
+			Configure:     func(c *Config) { c.AssumeRoleARN = "role/test" },
+			ExpectedError: regexp.MustCompile(`assume role 0: invalid role ARN \(role/test\)`),
+		},
+		{
+			TestName: "assume role duration too short",
+			Configure: func(c *Config) {
+				c.AssumeRoleARN = "arn:aws:iam::123456789012:role/test"
+				c.AssumeRoleDurationSeconds = 300
+			},
+			ExpectedError: regexp.MustCompile(`assume role 0: duration \(300 seconds\) must be between 900 and 43200 seconds`),
+		},
+		{
+			TestName: "assume role duration too long",
+			Configure: func(c *Config) {
+				c.AssumeRoleChain = []AssumeRoleConfig{{RoleARN: "arn:aws:iam::123456789012:role/test", DurationSeconds: 50000}}
+			},
+			ExpectedError: regexp.MustCompile(`assume role 0: duration \(50000 seconds\) must be between 900 and 43200 seconds`),
+		},
+		{
+			TestName: "assume role duration over an hour",
+			Configure: func(c *Config) {
+				c.AssumeRoleARN = "arn:aws:iam::123456789012:role/test"
+				c.AssumeRoleDurationSeconds = 7200
+			},
+		},
+		{
+			TestName:      "malformed SSO Admin role ARN",
+			Configure:     func(c *Config) { c.SSOAdminRoleARN = "sso-admin" },
+			ExpectedError: regexp.MustCompile(`invalid SSO Admin role ARN \(sso-admin\)`),
+		},
+		{
+			TestName: "allowed and forbidden account IDs",
+			Configure: func(c *Config) {
+				c.AllowedAccountIds = []string{"123456789012"}
+				c.ForbiddenAccountIds = []string{"210987654321"}
+			},
+			ExpectedError: regexp.MustCompile(`allowed_account_ids and forbidden_account_ids cannot both be configured`),
+		},
+		{
+			TestName:      "unknown retry mode",
+			Configure:     func(c *Config) { c.RetryMode = "legacy" },
+			ExpectedError: regexp.MustCompile(`unsupported retry mode \(legacy\)`),
+		},
+		{
+			TestName:      "unknown STS regional endpoint",
+			Configure:     func(c *Config) { c.STSRegionalEndpoint = "global-ish" },
+			ExpectedError: regexp.MustCompile(`error setting STS regional endpoint`),
+		},
+		{
+			TestName:      "unknown EC2 metadata service endpoint mode",
+			Configure:     func(c *Config) { c.EC2MetadataServiceEndpointMode = "IPv5" },
+			ExpectedError: regexp.MustCompile(`error setting EC2 metadata service endpoint mode`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+			testCase.Configure(config)
+
+			err := config.Validate()
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+		})
+	}
+}
+
+func TestConfigSetDefaultRegion(t *testing.T) {
+	testCases := []struct {
+		TestName             string
+		Region               string
+		RoleARN              string
+		SkipRegionValidation bool
+		ExpectedRegion       string
+	}{
+		{
+			TestName:             "GovCloud",
+			RoleARN:              "arn:aws-us-gov:iam::123456789012:role/test",
+			SkipRegionValidation: true,
+			ExpectedRegion:       "us-gov-west-1",
+		},
+		{
+			TestName:             "China",
+			RoleARN:              "arn:aws-cn:iam::123456789012:role/test",
+			SkipRegionValidation: true,
+			ExpectedRegion:       "cn-north-1",
+		},
+		{
+			TestName:             "commercial",
+			RoleARN:              "arn:aws:iam::123456789012:role/test",
+			SkipRegionValidation: true,
+		},
+		{
+			TestName:             "no role",
+			SkipRegionValidation: true,
+		},
+		{
+			TestName: "region validation",
+			RoleARN:  "arn:aws-us-gov:iam::123456789012:role/test",
+		},
+		{
+			TestName:             "configured region",
+			Region:               "us-gov-east-1",
+			RoleARN:              "arn:aws-us-gov:iam::123456789012:role/test",
+			SkipRegionValidation: true,
+			ExpectedRegion:       "us-gov-east-1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			config := testConfig()
+			config.Region = testCase.Region
+			config.SSOAdminRoleARN = testCase.RoleARN
+			config.SkipRegionValidation = testCase.SkipRegionValidation
+
+			config.setDefaultRegion()
+
+			if got := config.Region; got != testCase.ExpectedRegion {
+				t.Errorf("got region %q, expected %q", got, testCase.ExpectedRegion)
+			}
+		})
+	}
+}
@@ -0,0 +1,444 @@
+package aws
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	awsbase "github.com/hashicorp/aws-sdk-go-base"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// sharedConfigFiles returns the ordered list of shared config and credentials
+// files the session loads, with later files taking precedence. It returns nil
+// when neither list is configured so that the SDK defaults and environment
+// variables apply.
+func (c *Config) sharedConfigFiles() ([]string, error) {
+	if len(c.SharedConfigFiles) == 0 && len(c.SharedCredentialsFiles) == 0 {
+		return nil, nil
+	}
+
+	configFiles := c.SharedConfigFiles
+	if len(configFiles) == 0 {
+		configFiles = []string{defaults.SharedConfigFilename()}
+	}
+
+	credentialsFiles := c.SharedCredentialsFiles
+	if len(credentialsFiles) == 0 {
+		credentialsFiles = []string{defaults.SharedCredentialsFilename()}
+
+		if c.CredsFilename != "" {
+			credentialsFiles = []string{c.CredsFilename}
+		}
+	}
+
+	var files []string
+
+	for _, file := range append(configFiles, credentialsFiles...) {
+		expanded, err := homedir.Expand(file)
+
+		if err != nil {
+			return nil, fmt.Errorf("error expanding shared config filename (%s): %w", file, err)
+		}
+
+		files = append(files, expanded)
+	}
+
+	return files, nil
+}
+
+// getCredentials mirrors awsbase.GetCredentials, additionally loading
+// session-derived credentials from the configured shared config files.
+func (c *Config) getCredentials(ctx context.Context, awsbaseConfig *awsbase.Config) (*awsCredentials.Credentials, error) {
+	assumeRoleChain, err := c.assumeRoleChain()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c.AssumeRoleWithWebIdentity != nil {
+		if len(assumeRoleChain) > 0 {
+			return nil, errors.New("assume_role and assume_role_with_web_identity cannot both be configured, use only one")
+		}
+
+		return c.webIdentityCredentials(ctx, awsbaseConfig)
+	}
+
+	sharedConfigFiles, err := c.sharedConfigFiles()
+
+	if err != nil {
+		return nil, err
+	}
+
+	usesSSO, err := c.profileUsesSSO(awsbaseConfig, sharedConfigFiles)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var creds *awsCredentials.Credentials
+
+	// SSO profiles are resolved by the session from the SSO token cache, so
+	// that static or environment credentials don't take precedence over them.
+	if usesSSO {
+		log.Printf("[INFO] Profile (%s) uses AWS SSO, deriving credentials from session", awsbaseConfig.Profile)
+
+		creds, err = c.getCredentialsFromSession(ctx, awsbaseConfig)
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		creds, err = c.getCredentialsFromChain(ctx, awsbaseConfig, sharedConfigFiles)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, role := range assumeRoleChain {
+		creds, err = c.assumeRoleCredentials(ctx, awsbaseConfig, role, creds)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return creds, nil
+}
+
+// getCredentialsFromChain loads static, environment or shared credentials
+// file credentials, falling back to session-derived credentials.
+func (c *Config) getCredentialsFromChain(ctx context.Context, awsbaseConfig *awsbase.Config, sharedConfigFiles []string) (*awsCredentials.Credentials, error) {
+	providers := []awsCredentials.Provider{
+		&awsCredentials.StaticProvider{Value: awsCredentials.Value{
+			AccessKeyID:     awsbaseConfig.AccessKey,
+			SecretAccessKey: awsbaseConfig.SecretKey,
+			SessionToken:    awsbaseConfig.Token,
+		}},
+		&awsCredentials.EnvProvider{},
+	}
+
+	// Explicit shared files are loaded by the session below, which supports
+	// more than one file and profiles such as SSO in the shared config.
+	if sharedConfigFiles == nil {
+		sharedCredentialsFilename, err := homedir.Expand(awsbaseConfig.CredsFilename)
+
+		if err != nil {
+			return nil, fmt.Errorf("error expanding shared credentials filename: %w", err)
+		}
+
+		providers = append(providers, &awsCredentials.SharedCredentialsProvider{
+			Filename: sharedCredentialsFilename,
+			Profile:  awsbaseConfig.Profile,
+		})
+	}
+
+	creds := awsCredentials.NewChainCredentials(providers)
+	cp, err := creds.GetWithContext(ctx)
+	if err != nil {
+		if tfawserr.ErrCodeEquals(err, "NoCredentialProviders") {
+			creds, err = c.getCredentialsFromSession(ctx, awsbaseConfig)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			return nil, fmt.Errorf("Error loading credentials for AWS Provider: %w", err)
+		}
+	} else {
+		log.Printf("[INFO] AWS Auth provider used: %q", cp.ProviderName)
+	}
+
+	return creds, nil
+}
+
+// profileUsesSSO returns whether the configured profile is an SSO profile in
+// the shared config files, referencing an sso-session or the legacy
+// sso_start_url. Explicit static credentials take precedence over profiles.
+func (c *Config) profileUsesSSO(awsbaseConfig *awsbase.Config, sharedConfigFiles []string) (bool, error) {
+	if awsbaseConfig.Profile == "" || awsbaseConfig.AccessKey != "" {
+		return false, nil
+	}
+
+	if sharedConfigFiles == nil {
+		sharedConfigFiles = []string{defaults.SharedConfigFilename()}
+
+		if v := os.Getenv("AWS_CONFIG_FILE"); v != "" {
+			sharedConfigFiles = []string{v}
+		}
+	}
+
+	for _, file := range sharedConfigFiles {
+		usesSSO, err := sharedConfigProfileUsesSSO(file, awsbaseConfig.Profile)
+
+		if err != nil {
+			return false, err
+		}
+
+		if usesSSO {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// sharedConfigProfileUsesSSO returns whether a profile section of the shared
+// config file sets sso_session or sso_start_url. Missing files are ignored.
+func sharedConfigProfileUsesSSO(filename, profile string) (bool, error) {
+	f, err := os.Open(filename)
+
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("error reading shared config file (%s): %w", filename, err)
+	}
+
+	defer f.Close()
+
+	var inProfile bool
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			fields := strings.Fields(strings.Trim(line, "[]"))
+			inProfile = (len(fields) == 1 && fields[0] == profile) || (len(fields) == 2 && fields[0] == "profile" && fields[1] == profile)
+			continue
+		}
+
+		if !inProfile {
+			continue
+		}
+
+		if parts := strings.SplitN(line, "=", 2); len(parts) == 2 {
+			switch strings.ToLower(strings.TrimSpace(parts[0])) {
+			case "sso_session", "sso_start_url":
+				return true, nil
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("error reading shared config file (%s): %w", filename, err)
+	}
+
+	return false, nil
+}
+
+// getCredentialsFromSession mirrors awsbase.GetCredentialsFromSession,
+// applying the shared session settings.
+func (c *Config) getCredentialsFromSession(ctx context.Context, awsbaseConfig *awsbase.Config) (*awsCredentials.Credentials, error) {
+	log.Printf("[INFO] Attempting to use session-derived credentials")
+
+	// Avoid setting HTTPClient here as it will prevent the ec2metadata
+	// client from automatically lowering the timeout to 1 second.
+	options := &session.Options{
+		Config: aws.Config{
+			EndpointResolver: awsbaseConfig.EndpointResolver(),
+			MaxRetries:       aws.Int(0),
+			Region:           aws.String(awsbaseConfig.Region),
+		},
+		Profile:           awsbaseConfig.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	}
+
+	if err := c.applySharedSessionOptions(options); err != nil {
+		return nil, err
+	}
+
+	// Credential providers such as web identity and SSO must use the
+	// configured proxy and TLS settings, at the cost of the shorter
+	// ec2metadata timeout.
+	if c.HTTPProxy != "" || c.CustomCABundle != "" || awsbaseConfig.Insecure {
+		if err := c.applyHTTPClientOptions(options, awsbaseConfig.Insecure); err != nil {
+			return nil, err
+		}
+	}
+
+	sess, err := session.NewSessionWithOptions(*options)
+	if err != nil {
+		if tfawserr.ErrCodeEquals(err, "NoCredentialProviders") {
+			return nil, awsbaseConfig.NewNoValidCredentialSourcesError(err)
+		}
+		return nil, fmt.Errorf("Error creating AWS session: %w", err)
+	}
+
+	creds := sess.Config.Credentials
+	cp, err := sess.Config.Credentials.GetWithContext(ctx)
+	if err != nil {
+		return nil, awsbaseConfig.NewNoValidCredentialSourcesError(err)
+	}
+
+	log.Printf("[INFO] Successfully derived credentials from session")
+	log.Printf("[INFO] AWS Auth provider used: %q", cp.ProviderName)
+	return creds, nil
+}
+
+// assumeRoleCredentials returns credentials for role, assumed using creds,
+// and verifies that the role can be assumed.
+func (c *Config) assumeRoleCredentials(ctx context.Context, awsbaseConfig *awsbase.Config, role AssumeRoleConfig, creds *awsCredentials.Credentials) (*awsCredentials.Credentials, error) {
+	log.Printf("[INFO] Attempting to AssumeRole %s (SessionName: %q, ExternalId: %q)",
+		role.RoleARN, role.SessionName, role.ExternalID)
+
+	stsClient, err := c.stsCredentialsClient(awsbaseConfig, creds)
+
+	if err != nil {
+		return nil, err
+	}
+
+	assumeRoleProvider := &stscreds.AssumeRoleProvider{
+		Client:  stsClient,
+		RoleARN: role.RoleARN,
+	}
+
+	if role.DurationSeconds > 0 {
+		assumeRoleProvider.Duration = time.Duration(role.DurationSeconds) * time.Second
+	}
+
+	if role.ExternalID != "" {
+		assumeRoleProvider.ExternalID = aws.String(role.ExternalID)
+	}
+
+	if role.Policy != "" {
+		assumeRoleProvider.Policy = aws.String(role.Policy)
+	}
+
+	for _, policyARN := range role.PolicyARNs {
+		assumeRoleProvider.PolicyArns = append(assumeRoleProvider.PolicyArns, &sts.PolicyDescriptorType{
+			Arn: aws.String(policyARN),
+		})
+	}
+
+	if role.SessionName != "" {
+		assumeRoleProvider.RoleSessionName = role.SessionName
+	}
+
+	if role.SourceIdentity != "" {
+		assumeRoleProvider.SourceIdentity = aws.String(role.SourceIdentity)
+	}
+
+	for k, v := range role.Tags {
+		assumeRoleProvider.Tags = append(assumeRoleProvider.Tags, &sts.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+
+	if len(role.TransitiveTagKeys) > 0 {
+		assumeRoleProvider.TransitiveTagKeys = aws.StringSlice(role.TransitiveTagKeys)
+	}
+
+	assumeRoleCreds := awsCredentials.NewChainCredentials([]awsCredentials.Provider{assumeRoleProvider})
+	if _, err := assumeRoleCreds.GetWithContext(ctx); err != nil {
+		return nil, awsbase.CannotAssumeRoleError{
+			Config: &awsbase.Config{AssumeRoleARN: role.RoleARN},
+			Err:    err,
+		}
+	}
+
+	return assumeRoleCreds, nil
+}
+
+// webIdentityCredentials returns credentials for the configured role, assumed
+// with a web identity token, and verifies that the role can be assumed.
+func (c *Config) webIdentityCredentials(ctx context.Context, awsbaseConfig *awsbase.Config) (*awsCredentials.Credentials, error) {
+	webIdentity := c.AssumeRoleWithWebIdentity
+
+	log.Printf("[INFO] Attempting to AssumeRoleWithWebIdentity %s (SessionName: %q)", webIdentity.RoleARN, webIdentity.SessionName)
+
+	// AssumeRoleWithWebIdentity requests are not signed, so no credentials are
+	// needed to call STS.
+	stsClient, err := c.stsCredentialsClient(awsbaseConfig, awsCredentials.AnonymousCredentials)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var tokenFetcher stscreds.TokenFetcher = webIdentityTokenValue(webIdentity.WebIdentityToken)
+
+	if webIdentity.WebIdentityTokenFile != "" {
+		tokenFile, err := homedir.Expand(webIdentity.WebIdentityTokenFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("error expanding web identity token filename: %w", err)
+		}
+
+		tokenFetcher = stscreds.FetchTokenPath(tokenFile)
+	}
+
+	webIdentityProvider := stscreds.NewWebIdentityRoleProviderWithOptions(stsClient, webIdentity.RoleARN, webIdentity.SessionName, tokenFetcher)
+
+	webIdentityCreds := awsCredentials.NewCredentials(webIdentityProvider)
+	if _, err := webIdentityCreds.GetWithContext(ctx); err != nil {
+		return nil, fmt.Errorf("error assuming role (%s) with web identity: %w", webIdentity.RoleARN, err)
+	}
+
+	return webIdentityCreds, nil
+}
+
+// webIdentityTokenValue is a stscreds.TokenFetcher for a token configured
+// directly rather than read from a file.
+type webIdentityTokenValue string
+
+func (v webIdentityTokenValue) FetchToken(ctx awsCredentials.Context) ([]byte, error) {
+	return []byte(v), nil
+}
+
+// stsCredentialsClient returns an STS client using creds for assuming roles.
+func (c *Config) stsCredentialsClient(awsbaseConfig *awsbase.Config, creds *awsCredentials.Credentials) (*sts.STS, error) {
+	stsRegionalEndpoint, err := c.stsRegionalEndpoint()
+
+	if err != nil {
+		return nil, err
+	}
+
+	options := &session.Options{
+		Config: aws.Config{
+			Credentials:          creds,
+			EndpointResolver:     awsbaseConfig.EndpointResolver(),
+			Region:               aws.String(awsbaseConfig.Region),
+			MaxRetries:           aws.Int(awsbaseConfig.MaxRetries),
+			STSRegionalEndpoint:  stsRegionalEndpoint,
+			UseDualStackEndpoint: c.dualStackEndpointState(),
+			UseFIPSEndpoint:      c.fipsEndpointState(),
+		},
+	}
+
+	if err := c.applyHTTPClientOptions(options, awsbaseConfig.Insecure); err != nil {
+		return nil, err
+	}
+
+	if awsbaseConfig.DebugLogging {
+		options.Config.LogLevel = aws.LogLevel(aws.LogDebugWithHTTPBody | aws.LogDebugWithRequestRetries | aws.LogDebugWithRequestErrors)
+		options.Config.Logger = awsbase.DebugLogger{}
+	}
+
+	sess, err := session.NewSessionWithOptions(*options)
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating assume role session: %w", err)
+	}
+
+	return sts.New(sess), nil
+}
@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+)
+
+func dataSourceAwsSsoAccountAssignments() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoAccountAssignmentsRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_assignments": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"principal_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"principal_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"truncated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoAccountAssignmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	accountID := d.Get("account_id").(string)
+	instanceArn := d.Get("instance_arn").(string)
+	permissionSetArn := d.Get("permission_set_arn").(string)
+
+	accountAssignments, truncated, err := finder.AccountAssignmentsWithLimit(ctx, conn, &ssoadmin.ListAccountAssignmentsInput{
+		AccountId:        aws.String(accountID),
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}, d.Get("max_results").(int))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing SSO Account Assignments for permission set (%s) and account (%s): %w", permissionSetArn, accountID, cleanAwsRequestError(err)))
+	}
+
+	d.SetId(strings.Join([]string{accountID, permissionSetArn, instanceArn}, ","))
+	d.Set("account_id", accountID)
+	d.Set("instance_arn", instanceArn)
+	d.Set("permission_set_arn", permissionSetArn)
+	d.Set("truncated", truncated)
+
+	if err := d.Set("account_assignments", flattenSsoAccountAssignments(accountAssignments)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting account_assignments: %w", err))
+	}
+
+	return nil
+}
+
+func flattenSsoAccountAssignments(accountAssignments []*ssoadmin.AccountAssignment) []interface{} {
+	result := make([]interface{}, 0, len(accountAssignments))
+
+	for _, accountAssignment := range accountAssignments {
+		result = append(result, map[string]interface{}{
+			"principal_id":   aws.StringValue(accountAssignment.PrincipalId),
+			"principal_type": aws.StringValue(accountAssignment.PrincipalType),
+		})
+	}
+
+	return result
+}
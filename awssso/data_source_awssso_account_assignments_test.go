@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoAccountAssignmentsRead(t *testing.T) {
+	testCases := []struct {
+		TestName                    string
+		MaxResults                  int
+		Responses                   []mockapi.Response
+		ExpectedAccountAssignments  []interface{}
+		ExpectedListAssignmentCalls int
+		ExpectedTruncated           bool
+	}{
+		{
+			TestName: "pagination",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{
+					"AccountAssignments": []interface{}{
+						map[string]interface{}{"PrincipalId": "user-1", "PrincipalType": "USER"},
+					},
+					"NextToken": "page-2",
+				}},
+				{Body: map[string]interface{}{
+					"AccountAssignments": []interface{}{
+						map[string]interface{}{"PrincipalId": "group-1", "PrincipalType": "GROUP"},
+					},
+				}},
+			},
+			ExpectedAccountAssignments: []interface{}{
+				map[string]interface{}{"principal_id": "user-1", "principal_type": "USER"},
+				map[string]interface{}{"principal_id": "group-1", "principal_type": "GROUP"},
+			},
+			ExpectedListAssignmentCalls: 2,
+		},
+		{
+			TestName:   "max results",
+			MaxResults: 1,
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{
+					"AccountAssignments": []interface{}{
+						map[string]interface{}{"PrincipalId": "user-1", "PrincipalType": "USER"},
+					},
+					"NextToken": "page-2",
+				}},
+				{Body: map[string]interface{}{
+					"AccountAssignments": []interface{}{
+						map[string]interface{}{"PrincipalId": "group-1", "PrincipalType": "GROUP"},
+					},
+				}},
+			},
+			ExpectedAccountAssignments: []interface{}{
+				map[string]interface{}{"principal_id": "user-1", "principal_type": "USER"},
+			},
+			ExpectedListAssignmentCalls: 2,
+			ExpectedTruncated:           true,
+		},
+		{
+			TestName:   "max results reached on last assignment",
+			MaxResults: 1,
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{
+					"AccountAssignments": []interface{}{
+						map[string]interface{}{"PrincipalId": "user-1", "PrincipalType": "USER"},
+					},
+					"NextToken": "page-2",
+				}},
+				{Body: map[string]interface{}{"AccountAssignments": []interface{}{}}},
+			},
+			ExpectedAccountAssignments: []interface{}{
+				map[string]interface{}{"principal_id": "user-1", "principal_type": "USER"},
+			},
+			ExpectedListAssignmentCalls: 2,
+		},
+		{
+			TestName: "empty",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{"AccountAssignments": []interface{}{}}},
+			},
+			ExpectedAccountAssignments:  []interface{}{},
+			ExpectedListAssignmentCalls: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ListAccountAssignments": testCase.Responses,
+			})
+
+			raw := map[string]interface{}{
+				"account_id":         "123456789012",
+				"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			}
+
+			if testCase.MaxResults != 0 {
+				raw["max_results"] = testCase.MaxResults
+			}
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoAccountAssignments().Schema, raw)
+
+			if diags := dataSourceAwsSsoAccountAssignmentsRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("error reading data source: %v", diags)
+			}
+
+			if got := d.Get("account_assignments").([]interface{}); !reflect.DeepEqual(got, testCase.ExpectedAccountAssignments) {
+				t.Errorf("got account_assignments %v, expected %v", got, testCase.ExpectedAccountAssignments)
+			}
+
+			if got := d.Get("truncated").(bool); got != testCase.ExpectedTruncated {
+				t.Errorf("got truncated %t, expected %t", got, testCase.ExpectedTruncated)
+			}
+
+			requests := api.Requests("ListAccountAssignments")
+
+			if got, expected := len(requests), testCase.ExpectedListAssignmentCalls; got != expected {
+				t.Fatalf("got %d ListAccountAssignments calls, expected %d", got, expected)
+			}
+
+			if got, expected := requests[0].Body["AccountId"], "123456789012"; got != expected {
+				t.Errorf("got AccountId %v, expected %s", got, expected)
+			}
+		})
+	}
+}
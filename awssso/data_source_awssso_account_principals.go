@@ -0,0 +1,127 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+)
+
+func dataSourceAwsSsoAccountPrincipals() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoAccountPrincipalsRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"principals": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"permission_set_arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"principal_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"principal_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoAccountPrincipalsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	accountID := d.Get("account_id").(string)
+	instanceArn := d.Get("instance_arn").(string)
+
+	// Permission sets with an outdated version provisioned still grant access,
+	// so they are included regardless of provisioning status.
+	permissionSetArns, err := finder.PermissionSetArnsProvisionedToAccount(ctx, conn, accountID, instanceArn, "")
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing SSO Permission Sets provisioned to account (%s) for instance (%s): %w", accountID, instanceArn, cleanAwsRequestError(err)))
+	}
+
+	var accountAssignments []*ssoadmin.AccountAssignment
+
+	for _, permissionSetArn := range permissionSetArns {
+		results, err := finder.AccountAssignments(ctx, conn, &ssoadmin.ListAccountAssignmentsInput{
+			AccountId:        aws.String(accountID),
+			InstanceArn:      aws.String(instanceArn),
+			PermissionSetArn: aws.String(permissionSetArn),
+		})
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error listing SSO Account Assignments for permission set (%s) and account (%s): %w", permissionSetArn, accountID, cleanAwsRequestError(err)))
+		}
+
+		for _, accountAssignment := range results {
+			// The permission set is taken from the request, as it is the one the
+			// assignment was listed under.
+			accountAssignment.PermissionSetArn = aws.String(permissionSetArn)
+			accountAssignments = append(accountAssignments, accountAssignment)
+		}
+	}
+
+	sort.SliceStable(accountAssignments, func(i, j int) bool {
+		a, b := accountAssignments[i], accountAssignments[j]
+
+		if aws.StringValue(a.PrincipalType) != aws.StringValue(b.PrincipalType) {
+			return aws.StringValue(a.PrincipalType) < aws.StringValue(b.PrincipalType)
+		}
+
+		if aws.StringValue(a.PrincipalId) != aws.StringValue(b.PrincipalId) {
+			return aws.StringValue(a.PrincipalId) < aws.StringValue(b.PrincipalId)
+		}
+
+		return aws.StringValue(a.PermissionSetArn) < aws.StringValue(b.PermissionSetArn)
+	})
+
+	d.SetId(strings.Join([]string{accountID, instanceArn}, ","))
+	d.Set("account_id", accountID)
+	d.Set("instance_arn", instanceArn)
+
+	if err := d.Set("principals", flattenSsoAccountPrincipals(accountAssignments)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting principals: %w", err))
+	}
+
+	return nil
+}
+
+func flattenSsoAccountPrincipals(accountAssignments []*ssoadmin.AccountAssignment) []interface{} {
+	result := make([]interface{}, 0, len(accountAssignments))
+
+	for _, accountAssignment := range accountAssignments {
+		result = append(result, map[string]interface{}{
+			"permission_set_arn": aws.StringValue(accountAssignment.PermissionSetArn),
+			"principal_id":       aws.StringValue(accountAssignment.PrincipalId),
+			"principal_type":     aws.StringValue(accountAssignment.PrincipalType),
+		})
+	}
+
+	return result
+}
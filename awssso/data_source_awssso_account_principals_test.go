@@ -0,0 +1,105 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoAccountPrincipalsRead(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ListPermissionSetsProvisionedToAccount": {
+			{Body: map[string]interface{}{
+				"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"},
+				"NextToken":      "page-2",
+			}},
+			{Body: map[string]interface{}{
+				"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222"},
+			}},
+		},
+		"ListAccountAssignments": {
+			{Body: map[string]interface{}{
+				"AccountAssignments": []interface{}{
+					map[string]interface{}{"AccountId": "123456789012", "PrincipalId": "group-1", "PrincipalType": "GROUP"},
+				},
+				"NextToken": "page-2",
+			}},
+			{Body: map[string]interface{}{
+				"AccountAssignments": []interface{}{
+					map[string]interface{}{"AccountId": "123456789012", "PrincipalId": "user-1", "PrincipalType": "USER"},
+				},
+			}},
+			{Body: map[string]interface{}{
+				"AccountAssignments": []interface{}{
+					map[string]interface{}{"AccountId": "123456789012", "PrincipalId": "group-1", "PrincipalType": "GROUP"},
+				},
+			}},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceAwsSsoAccountPrincipals().Schema, map[string]interface{}{
+		"account_id":   "123456789012",
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+	})
+
+	if diags := dataSourceAwsSsoAccountPrincipalsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading data source: %v", diags)
+	}
+
+	expectedPrincipals := []interface{}{
+		map[string]interface{}{
+			"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			"principal_id":       "group-1",
+			"principal_type":     "GROUP",
+		},
+		map[string]interface{}{
+			"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+			"principal_id":       "group-1",
+			"principal_type":     "GROUP",
+		},
+		map[string]interface{}{
+			"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			"principal_id":       "user-1",
+			"principal_type":     "USER",
+		},
+	}
+
+	if got := d.Get("principals").([]interface{}); !reflect.DeepEqual(got, expectedPrincipals) {
+		t.Errorf("got principals %v, expected %v", got, expectedPrincipals)
+	}
+
+	if got, expected := d.Id(), "123456789012,arn:aws:sso:::instance/ssoins-1111111111111111"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+
+	provisioned := api.Requests("ListPermissionSetsProvisionedToAccount")
+
+	if got, expected := len(provisioned), 2; got != expected {
+		t.Fatalf("got %d ListPermissionSetsProvisionedToAccount calls, expected %d", got, expected)
+	}
+
+	if got, ok := provisioned[0].Body["ProvisioningStatus"]; ok {
+		t.Errorf("expected permission sets of any provisioning status to be listed, got ProvisioningStatus %v", got)
+	}
+
+	requests := api.Requests("ListAccountAssignments")
+
+	if got, expected := len(requests), 3; got != expected {
+		t.Fatalf("got %d ListAccountAssignments calls, expected %d", got, expected)
+	}
+
+	expectedPermissionSetArns := []string{
+		"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+	}
+
+	for i, expected := range expectedPermissionSetArns {
+		if got := requests[i].Body["PermissionSetArn"]; got != expected {
+			t.Errorf("got PermissionSetArn %v for ListAccountAssignments call %d, expected %s", got, i, expected)
+		}
+	}
+}
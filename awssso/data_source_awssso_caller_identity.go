@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsSsoCallerIdentity() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoCallerIdentityRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoCallerIdentityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).STSConn()
+
+	output, err := conn.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error getting caller identity: %w", cleanAwsRequestError(err)))
+	}
+
+	if output == nil {
+		return diag.Errorf("error getting caller identity: empty output")
+	}
+
+	d.SetId(aws.StringValue(output.Account))
+	d.Set("account_id", output.Account)
+	d.Set("arn", output.Arn)
+	d.Set("user_id", output.UserId)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceAwsSsoCallerIdentityRead(t *testing.T) {
+	var actions []string
+
+	stsURL := testMockSTS(t, func(r *http.Request) string {
+		actions = append(actions, r.PostForm.Get("Action"))
+
+		return `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:sts::123456789012:assumed-role/Admin/terraform</Arn>
+    <UserId>AROAEXAMPLE:terraform</UserId>
+    <Account>123456789012</Account>
+  </GetCallerIdentityResult>
+</GetCallerIdentityResponse>`
+	})
+
+	config := testConfig()
+	config.Endpoints["sts"] = stsURL
+
+	client := testConfigClient(t, config)
+
+	d := schema.TestResourceDataRaw(t, dataSourceAwsSsoCallerIdentity().Schema, map[string]interface{}{})
+
+	if diags := dataSourceAwsSsoCallerIdentityRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading data source: %v", diags)
+	}
+
+	if got, expected := len(actions), 1; got != expected {
+		t.Fatalf("got %d STS requests, expected %d", got, expected)
+	}
+
+	if got, expected := actions[0], "GetCallerIdentity"; got != expected {
+		t.Errorf("got Action %s, expected %s", got, expected)
+	}
+
+	for k, expected := range map[string]string{
+		"account_id": "123456789012",
+		"arn":        "arn:aws:sts::123456789012:assumed-role/Admin/terraform",
+		"user_id":    "AROAEXAMPLE:terraform",
+	} {
+		if got := d.Get(k).(string); got != expected {
+			t.Errorf("got %s %s, expected %s", k, got, expected)
+		}
+	}
+
+	if got, expected := d.Id(), "123456789012"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+}
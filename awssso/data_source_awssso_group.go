@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/identitystore/finder"
+)
+
+func dataSourceAwsSsoGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 1024),
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"identity_store_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 36),
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	identityStoreID := d.Get("identity_store_id").(string)
+	displayName := d.Get("display_name").(string)
+
+	groups, err := finder.GroupsByDisplayName(ctx, conn, identityStoreID, displayName)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Identity Store (%s) groups: %w", identityStoreID, cleanAwsRequestError(err)))
+	}
+
+	if len(groups) == 0 {
+		return diag.Errorf("couldn't find any Identity Store groups with display name (%s)", displayName)
+	}
+
+	if len(groups) > 1 {
+		return diag.Errorf("found too many Identity Store groups (%d) with display name (%s), use a more specific display name", len(groups), displayName)
+	}
+
+	group := groups[0]
+
+	d.SetId(aws.StringValue(group.GroupId))
+	d.Set("description", group.Description)
+	d.Set("display_name", group.DisplayName)
+	d.Set("group_id", group.GroupId)
+	d.Set("identity_store_id", identityStoreID)
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/identitystore/finder"
+)
+
+func dataSourceAwsSsoGroupMemberships() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoGroupMembershipsRead,
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 47),
+			},
+			"identity_store_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 36),
+			},
+			"user_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoGroupMembershipsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	groupID := d.Get("group_id").(string)
+	identityStoreID := d.Get("identity_store_id").(string)
+
+	memberships, err := finder.GroupMemberships(ctx, conn, identityStoreID, groupID)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing Identity Store (%s) group (%s) memberships: %w", identityStoreID, groupID, cleanAwsRequestError(err)))
+	}
+
+	userIDs := make([]string, 0, len(memberships))
+
+	for _, membership := range memberships {
+		if membership.MemberId == nil || membership.MemberId.UserId == nil {
+			continue
+		}
+
+		userIDs = append(userIDs, aws.StringValue(membership.MemberId.UserId))
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s", groupID, identityStoreID))
+	d.Set("group_id", groupID)
+	d.Set("identity_store_id", identityStoreID)
+
+	if err := d.Set("user_ids", userIDs); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting user_ids: %w", err))
+	}
+
+	return nil
+}
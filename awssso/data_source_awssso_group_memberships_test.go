@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func testGroupMembership(userID string) map[string]interface{} {
+	return map[string]interface{}{
+		"GroupId":         "11111111-1111-1111-1111-111111111111",
+		"IdentityStoreId": "d-1111111111",
+		"MemberId":        map[string]interface{}{"UserId": userID},
+		"MembershipId":    "m-" + userID,
+	}
+}
+
+func TestDataSourceAwsSsoGroupMembershipsRead(t *testing.T) {
+	testCases := []struct {
+		TestName        string
+		Responses       []mockapi.Response
+		ExpectedUserIDs []string
+	}{
+		{
+			TestName: "paginated",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{
+					"GroupMemberships": []interface{}{testGroupMembership("22222222-2222-2222-2222-222222222222")},
+					"NextToken":        "page-2",
+				}},
+				{Body: map[string]interface{}{
+					"GroupMemberships": []interface{}{testGroupMembership("33333333-3333-3333-3333-333333333333")},
+				}},
+			},
+			ExpectedUserIDs: []string{"22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333"},
+		},
+		{
+			TestName: "empty group",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{"GroupMemberships": []interface{}{}}},
+			},
+			ExpectedUserIDs: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ListGroupMemberships": testCase.Responses,
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoGroupMemberships().Schema, map[string]interface{}{
+				"group_id":          "11111111-1111-1111-1111-111111111111",
+				"identity_store_id": "d-1111111111",
+			})
+
+			if diags := dataSourceAwsSsoGroupMembershipsRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("error reading data source: %v", diags)
+			}
+
+			if got, expected := d.Id(), "11111111-1111-1111-1111-111111111111,d-1111111111"; got != expected {
+				t.Errorf("got ID %s, expected %s", got, expected)
+			}
+
+			userIDs := []string{}
+
+			for _, v := range d.Get("user_ids").(*schema.Set).List() {
+				userIDs = append(userIDs, v.(string))
+			}
+
+			sort.Strings(userIDs)
+
+			if !reflect.DeepEqual(userIDs, testCase.ExpectedUserIDs) {
+				t.Errorf("got user_ids %v, expected %v", userIDs, testCase.ExpectedUserIDs)
+			}
+
+			if got, expected := len(api.Requests("ListGroupMemberships")), len(testCase.Responses); got != expected {
+				t.Errorf("got %d ListGroupMemberships calls, expected %d", got, expected)
+			}
+		})
+	}
+}
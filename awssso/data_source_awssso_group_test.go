@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoGroupRead(t *testing.T) {
+	testCases := []struct {
+		TestName            string
+		Groups              []map[string]interface{}
+		ExpectedError       *regexp.Regexp
+		ExpectedGroupID     string
+		ExpectedDescription string
+	}{
+		{
+			TestName:      "no groups",
+			Groups:        []map[string]interface{}{},
+			ExpectedError: regexp.MustCompile(`couldn't find any Identity Store groups`),
+		},
+		{
+			TestName: "single group",
+			Groups: []map[string]interface{}{
+				{"GroupId": "11111111-1111-1111-1111-111111111111", "DisplayName": "Engineering", "Description": "Engineers", "IdentityStoreId": "d-1111111111"},
+			},
+			ExpectedGroupID:     "11111111-1111-1111-1111-111111111111",
+			ExpectedDescription: "Engineers",
+		},
+		{
+			TestName: "multiple groups",
+			Groups: []map[string]interface{}{
+				{"GroupId": "11111111-1111-1111-1111-111111111111", "DisplayName": "Engineering", "IdentityStoreId": "d-1111111111"},
+				{"GroupId": "22222222-2222-2222-2222-222222222222", "DisplayName": "Engineering", "IdentityStoreId": "d-1111111111"},
+			},
+			ExpectedError: regexp.MustCompile(`found too many Identity Store groups \(2\)`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ListGroups": {{Body: map[string]interface{}{"Groups": testCase.Groups}}},
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoGroup().Schema, map[string]interface{}{
+				"display_name":      "Engineering",
+				"identity_store_id": "d-1111111111",
+			})
+
+			diags := dataSourceAwsSsoGroupRead(context.Background(), d, client)
+
+			if !diags.HasError() && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if diags.HasError() && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %v", diags)
+			}
+
+			if diags.HasError() && !testCase.ExpectedError.MatchString(diags[0].Summary) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+			}
+
+			requests := api.Requests("ListGroups")
+
+			if got, expected := len(requests), 1; got != expected {
+				t.Fatalf("got %d ListGroups calls, expected %d", got, expected)
+			}
+
+			if got, expected := requests[0].Body["IdentityStoreId"], "d-1111111111"; got != expected {
+				t.Errorf("got IdentityStoreId %v, expected %s", got, expected)
+			}
+
+			if got := d.Get("group_id").(string); got != testCase.ExpectedGroupID {
+				t.Errorf("got group_id %s, expected %s", got, testCase.ExpectedGroupID)
+			}
+
+			if got := d.Get("description").(string); got != testCase.ExpectedDescription {
+				t.Errorf("got description %s, expected %s", got, testCase.ExpectedDescription)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/identitystore/finder"
+)
+
+func dataSourceAwsSsoGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoGroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"display_names": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringLenBetween(1, 1024),
+				},
+			},
+			"group_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"identity_store_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 36),
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	identityStoreID := d.Get("identity_store_id").(string)
+
+	var displayNames []string
+
+	for _, v := range d.Get("display_names").(*schema.Set).List() {
+		displayNames = append(displayNames, v.(string))
+	}
+
+	sort.Strings(displayNames)
+
+	// ListGroups accepts a single filter, so each display name is resolved
+	// with its own request.
+	groupIDs := make(map[string]string, len(displayNames))
+	var missing []string
+
+	for _, displayName := range displayNames {
+		groups, err := finder.GroupsByDisplayName(ctx, conn, identityStoreID, displayName)
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading Identity Store (%s) groups: %w", identityStoreID, cleanAwsRequestError(err)))
+		}
+
+		if len(groups) == 0 {
+			missing = append(missing, displayName)
+			continue
+		}
+
+		if len(groups) > 1 {
+			return diag.Errorf("found too many Identity Store groups (%d) with display name (%s), use a more specific display name", len(groups), displayName)
+		}
+
+		groupIDs[displayName] = aws.StringValue(groups[0].GroupId)
+	}
+
+	if len(missing) > 0 {
+		return diag.Errorf("couldn't find any Identity Store groups with display names (%s)", strings.Join(missing, ", "))
+	}
+
+	d.SetId(identityStoreID)
+	d.Set("group_ids", groupIDs)
+	d.Set("identity_store_id", identityStoreID)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoGroupsRead(t *testing.T) {
+	testCases := []struct {
+		TestName         string
+		Responses        []mockapi.Response
+		ExpectedError    *regexp.Regexp
+		ExpectedGroupIDs map[string]interface{}
+	}{
+		{
+			TestName: "all found",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{"Groups": []map[string]interface{}{{"GroupId": "11111111-1111-1111-1111-111111111111", "DisplayName": "Admins", "IdentityStoreId": "d-1111111111"}}}},
+				{Body: map[string]interface{}{"Groups": []map[string]interface{}{{"GroupId": "22222222-2222-2222-2222-222222222222", "DisplayName": "Engineering", "IdentityStoreId": "d-1111111111"}}}},
+				{Body: map[string]interface{}{"Groups": []map[string]interface{}{{"GroupId": "33333333-3333-3333-3333-333333333333", "DisplayName": "Finance", "IdentityStoreId": "d-1111111111"}}}},
+			},
+			ExpectedGroupIDs: map[string]interface{}{
+				"Admins":      "11111111-1111-1111-1111-111111111111",
+				"Engineering": "22222222-2222-2222-2222-222222222222",
+				"Finance":     "33333333-3333-3333-3333-333333333333",
+			},
+		},
+		{
+			TestName: "one missing",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{"Groups": []map[string]interface{}{{"GroupId": "11111111-1111-1111-1111-111111111111", "DisplayName": "Admins", "IdentityStoreId": "d-1111111111"}}}},
+				{Body: map[string]interface{}{"Groups": []map[string]interface{}{}}},
+				{Body: map[string]interface{}{"Groups": []map[string]interface{}{{"GroupId": "33333333-3333-3333-3333-333333333333", "DisplayName": "Finance", "IdentityStoreId": "d-1111111111"}}}},
+			},
+			ExpectedError:    regexp.MustCompile(`couldn't find any Identity Store groups with display names \(Engineering\)`),
+			ExpectedGroupIDs: map[string]interface{}{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ListGroups": testCase.Responses,
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoGroups().Schema, map[string]interface{}{
+				"display_names":     []interface{}{"Finance", "Admins", "Engineering"},
+				"identity_store_id": "d-1111111111",
+			})
+
+			diags := dataSourceAwsSsoGroupsRead(context.Background(), d, client)
+
+			if !diags.HasError() && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if diags.HasError() && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %v", diags)
+			}
+
+			if diags.HasError() && !testCase.ExpectedError.MatchString(diags[0].Summary) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+			}
+
+			requests := api.Requests("ListGroups")
+
+			if got, expected := len(requests), 3; got != expected {
+				t.Fatalf("got %d ListGroups calls, expected %d", got, expected)
+			}
+
+			for i, expected := range []string{"Admins", "Engineering", "Finance"} {
+				filters, _ := requests[i].Body["Filters"].([]interface{})
+
+				if len(filters) != 1 {
+					t.Fatalf("got %d filters in ListGroups call %d, expected 1", len(filters), i)
+				}
+
+				if got := filters[0].(map[string]interface{})["AttributeValue"]; got != expected {
+					t.Errorf("got AttributeValue %v in ListGroups call %d, expected %s", got, i, expected)
+				}
+			}
+
+			if got, expected := d.Get("group_ids").(map[string]interface{}), testCase.ExpectedGroupIDs; !reflect.DeepEqual(got, expected) {
+				t.Errorf("got group_ids %v, expected %v", got, expected)
+			}
+		})
+	}
+}
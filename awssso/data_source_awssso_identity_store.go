@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsSsoIdentityStore() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoIdentityStoreRead,
+
+		Schema: map[string]*schema.Schema{
+			"identity_store_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoIdentityStoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instance, err := findSsoInstance(ctx, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	identityStoreID := aws.StringValue(instance.IdentityStoreId)
+
+	d.SetId(identityStoreID)
+	d.Set("identity_store_id", identityStoreID)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoIdentityStoreRead(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ListInstances": {{Body: map[string]interface{}{"Instances": []map[string]interface{}{
+			{"InstanceArn": "arn:aws:sso:::instance/ssoins-1111111111111111", "IdentityStoreId": "d-1111111111"},
+		}}}},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceAwsSsoIdentityStore().Schema, map[string]interface{}{})
+
+	if diags := dataSourceAwsSsoIdentityStoreRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading data source: %v", diags)
+	}
+
+	if got, expected := len(api.Requests("ListInstances")), 1; got != expected {
+		t.Fatalf("got %d ListInstances calls, expected %d", got, expected)
+	}
+
+	if got, expected := d.Id(), "d-1111111111"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := d.Get("identity_store_id").(string), "d-1111111111"; got != expected {
+		t.Errorf("got identity_store_id %s, expected %s", got, expected)
+	}
+}
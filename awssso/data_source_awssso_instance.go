@@ -0,0 +1,148 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/waiter"
+)
+
+// ErrMultipleInstances is returned when SSO instance discovery finds more than
+// one instance, such as in organizations with delegated administration.
+var ErrMultipleInstances = errors.New("found too many SSO instances")
+
+func dataSourceAwsSsoInstance() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoInstanceRead,
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"identity_store_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"instance_arn"},
+				ValidateFunc: validation.StringLenBetween(1, 64),
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	// A known instance ARN skips ListInstances, which callers such as CI roles
+	// may not be allowed to call.
+	if v, ok := d.GetOk("instance_arn"); ok {
+		arn := v.(string)
+		identityStoreID := d.Get("identity_store_id").(string)
+
+		if identityStoreID == "" {
+			output, err := conn.DescribeInstanceWithContext(ctx, &ssoadmin.DescribeInstanceInput{
+				InstanceArn: aws.String(arn),
+			})
+
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error reading SSO instance (%s): %w", arn, cleanAwsRequestError(err)))
+			}
+
+			if output == nil {
+				return diag.Errorf("error reading SSO instance (%s): empty output", arn)
+			}
+
+			identityStoreID = aws.StringValue(output.IdentityStoreId)
+		}
+
+		d.SetId(arn)
+		d.Set("arn", arn)
+		d.Set("identity_store_id", identityStoreID)
+
+		return nil
+	}
+
+	instance, err := findSsoInstance(ctx, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	arn := aws.StringValue(instance.InstanceArn)
+
+	d.SetId(arn)
+	d.Set("arn", arn)
+	d.Set("identity_store_id", instance.IdentityStoreId)
+
+	return nil
+}
+
+// findSsoInstance returns the single SSO instance visible to the caller,
+// wrapping ErrMultipleInstances when more than one instance exists.
+func findSsoInstance(ctx context.Context, conn *ssoadmin.SSOAdmin) (*ssoadmin.InstanceMetadata, error) {
+	var instances []*ssoadmin.InstanceMetadata
+
+	// ListInstances can be throttled in organizations with many accounts.
+	err := waiter.RetryThrottled(ctx, conn, waiter.InstancesThrottledTimeout, func() error {
+		var err error
+
+		instances, err = finder.Instances(ctx, conn)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading SSO instances: %w", cleanAwsRequestError(err))
+	}
+
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("couldn't find any SSO instances")
+	}
+
+	if len(instances) > 1 {
+		arns := make([]string, 0, len(instances))
+
+		for _, instance := range instances {
+			arns = append(arns, aws.StringValue(instance.InstanceArn))
+		}
+
+		return nil, fmt.Errorf("%w (%d): %s", ErrMultipleInstances, len(instances), strings.Join(arns, ", "))
+	}
+
+	return instances[0], nil
+}
+
+// ssoInstanceArn returns the configured instance_arn of a resource. When it is
+// omitted, the single SSO instance visible to the caller is discovered and set
+// as the instance_arn.
+func ssoInstanceArn(ctx context.Context, d *schema.ResourceData, conn *ssoadmin.SSOAdmin) (string, error) {
+	if v, ok := d.GetOk("instance_arn"); ok {
+		return v.(string), nil
+	}
+
+	instance, err := findSsoInstance(ctx, conn)
+
+	if err != nil {
+		return "", fmt.Errorf("error discovering instance_arn, configure it explicitly: %w", err)
+	}
+
+	instanceArn := aws.StringValue(instance.InstanceArn)
+
+	d.Set("instance_arn", instanceArn)
+
+	return instanceArn, nil
+}
@@ -0,0 +1,194 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoInstanceRead(t *testing.T) {
+	testCases := []struct {
+		TestName                string
+		Instances               []map[string]interface{}
+		ExpectedError           *regexp.Regexp
+		ExpectedArn             string
+		ExpectedIdentityStoreID string
+	}{
+		{
+			TestName:      "no instances",
+			Instances:     []map[string]interface{}{},
+			ExpectedError: regexp.MustCompile(`couldn't find any SSO instances`),
+		},
+		{
+			TestName: "single instance",
+			Instances: []map[string]interface{}{
+				{"InstanceArn": "arn:aws:sso:::instance/ssoins-1111111111111111", "IdentityStoreId": "d-1111111111"},
+			},
+			ExpectedArn:             "arn:aws:sso:::instance/ssoins-1111111111111111",
+			ExpectedIdentityStoreID: "d-1111111111",
+		},
+		{
+			TestName: "multiple instances",
+			Instances: []map[string]interface{}{
+				{"InstanceArn": "arn:aws:sso:::instance/ssoins-1111111111111111", "IdentityStoreId": "d-1111111111"},
+				{"InstanceArn": "arn:aws:sso:::instance/ssoins-2222222222222222", "IdentityStoreId": "d-2222222222"},
+			},
+			ExpectedError: regexp.MustCompile(`found too many SSO instances \(2\): arn:aws:sso:::instance/ssoins-1111111111111111, arn:aws:sso:::instance/ssoins-2222222222222222`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, _ := testMockClient(t, map[string][]mockapi.Response{
+				"ListInstances": {{Body: map[string]interface{}{"Instances": testCase.Instances}}},
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoInstance().Schema, map[string]interface{}{})
+
+			diags := dataSourceAwsSsoInstanceRead(context.Background(), d, client)
+
+			if !diags.HasError() && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if diags.HasError() && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %v", diags)
+			}
+
+			if diags.HasError() && !testCase.ExpectedError.MatchString(diags[0].Summary) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+			}
+
+			if got := d.Get("arn").(string); got != testCase.ExpectedArn {
+				t.Errorf("got arn %s, expected %s", got, testCase.ExpectedArn)
+			}
+
+			if got := d.Get("identity_store_id").(string); got != testCase.ExpectedIdentityStoreID {
+				t.Errorf("got identity_store_id %s, expected %s", got, testCase.ExpectedIdentityStoreID)
+			}
+		})
+	}
+}
+
+func TestFindSsoInstance_multipleInstances(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"ListInstances": {{Body: map[string]interface{}{
+			"Instances": []interface{}{
+				map[string]interface{}{"InstanceArn": "arn:aws:sso:::instance/ssoins-1111111111111111", "IdentityStoreId": "d-1111111111"},
+				map[string]interface{}{"InstanceArn": "arn:aws:sso:::instance/ssoins-2222222222222222", "IdentityStoreId": "d-2222222222"},
+			},
+		}}},
+	})
+
+	_, err := findSsoInstance(context.Background(), client.SSOAdminConn())
+
+	if !errors.Is(err, ErrMultipleInstances) {
+		t.Fatalf("expected ErrMultipleInstances, got: %v", err)
+	}
+}
+
+func TestDataSourceAwsSsoInstanceRead_instanceArn(t *testing.T) {
+	testCases := []struct {
+		TestName              string
+		IdentityStoreID       string
+		ExpectedDescribeCalls int
+	}{
+		{
+			TestName:              "describe instance",
+			ExpectedDescribeCalls: 1,
+		},
+		{
+			TestName:        "identity store ID",
+			IdentityStoreID: "d-1111111111",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"DescribeInstance": {{Body: map[string]interface{}{
+					"InstanceArn":     "arn:aws:sso:::instance/ssoins-1111111111111111",
+					"IdentityStoreId": "d-1111111111",
+				}}},
+			})
+
+			raw := map[string]interface{}{
+				"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+			}
+
+			if testCase.IdentityStoreID != "" {
+				raw["identity_store_id"] = testCase.IdentityStoreID
+			}
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoInstance().Schema, raw)
+
+			if diags := dataSourceAwsSsoInstanceRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("got unexpected error: %v", diags)
+			}
+
+			if got := len(api.Requests("ListInstances")); got != 0 {
+				t.Errorf("got %d ListInstances calls, expected none", got)
+			}
+
+			if got, expected := len(api.Requests("DescribeInstance")), testCase.ExpectedDescribeCalls; got != expected {
+				t.Errorf("got %d DescribeInstance calls, expected %d", got, expected)
+			}
+
+			if got, expected := d.Get("arn").(string), "arn:aws:sso:::instance/ssoins-1111111111111111"; got != expected {
+				t.Errorf("got arn %s, expected %s", got, expected)
+			}
+
+			if got, expected := d.Get("identity_store_id").(string), "d-1111111111"; got != expected {
+				t.Errorf("got identity_store_id %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestFindSsoInstance_throttled(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ListInstances": {
+			{ErrorCode: ssoadmin.ErrCodeThrottlingException},
+			{Body: map[string]interface{}{
+				"Instances": []interface{}{
+					map[string]interface{}{"InstanceArn": "arn:aws:sso:::instance/ssoins-1111111111111111", "IdentityStoreId": "d-1111111111"},
+				},
+			}},
+		},
+	})
+
+	instance, err := findSsoInstance(context.Background(), client.SSOAdminConn())
+
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	if got, expected := aws.StringValue(instance.InstanceArn), "arn:aws:sso:::instance/ssoins-1111111111111111"; got != expected {
+		t.Errorf("got instance ARN %s, expected %s", got, expected)
+	}
+
+	if got, expected := len(api.Requests("ListInstances")), 2; got != expected {
+		t.Errorf("got %d ListInstances calls, expected %d", got, expected)
+	}
+}
+
+func TestDataSourceAwsSsoInstance_identityStoreIDRequiresInstanceArn(t *testing.T) {
+	diags := dataSourceAwsSsoInstance().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"identity_store_id": "d-1111111111",
+	}))
+
+	if !diags.HasError() {
+		t.Fatal("expected error validating identity_store_id without instance_arn, got none")
+	}
+
+	if got, expected := diags[0].Detail, regexp.MustCompile(`"identity_store_id": all of `+"`identity_store_id,instance_arn`"+` must be specified`); !expected.MatchString(got) {
+		t.Errorf("got error %q, expected to match %s", got, expected)
+	}
+}
@@ -0,0 +1,79 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+)
+
+func dataSourceAwsSsoManagedPoliciesInPermissionSet() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoManagedPoliciesInPermissionSetRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"managed_policies": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoManagedPoliciesInPermissionSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn := d.Get("instance_arn").(string)
+	permissionSetArn := d.Get("permission_set_arn").(string)
+
+	policies, err := finder.ManagedPolicies(ctx, conn, permissionSetArn, instanceArn)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing Managed Policies for SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	managedPolicies := make([]interface{}, 0, len(policies))
+
+	for _, policy := range policies {
+		managedPolicies = append(managedPolicies, map[string]interface{}{
+			"arn":  aws.StringValue(policy.Arn),
+			"name": aws.StringValue(policy.Name),
+		})
+	}
+
+	d.SetId(strings.Join([]string{permissionSetArn, instanceArn}, ","))
+	d.Set("instance_arn", instanceArn)
+	d.Set("permission_set_arn", permissionSetArn)
+
+	if err := d.Set("managed_policies", managedPolicies); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting managed_policies: %w", err))
+	}
+
+	return nil
+}
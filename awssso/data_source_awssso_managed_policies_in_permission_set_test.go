@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoManagedPoliciesInPermissionSetRead(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ListManagedPoliciesInPermissionSet": {
+			{Body: map[string]interface{}{
+				"AttachedManagedPolicies": []interface{}{
+					map[string]interface{}{"Arn": "arn:aws:iam::aws:policy/AdministratorAccess", "Name": "AdministratorAccess"},
+				},
+				"NextToken": "page-2",
+			}},
+			{Body: map[string]interface{}{
+				"AttachedManagedPolicies": []interface{}{
+					map[string]interface{}{"Arn": "arn:aws:iam::aws:policy/ReadOnlyAccess", "Name": "ReadOnlyAccess"},
+				},
+			}},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceAwsSsoManagedPoliciesInPermissionSet().Schema, map[string]interface{}{
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	})
+
+	if diags := dataSourceAwsSsoManagedPoliciesInPermissionSetRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading data source: %v", diags)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"arn": "arn:aws:iam::aws:policy/AdministratorAccess", "name": "AdministratorAccess"},
+		map[string]interface{}{"arn": "arn:aws:iam::aws:policy/ReadOnlyAccess", "name": "ReadOnlyAccess"},
+	}
+
+	if got := d.Get("managed_policies").([]interface{}); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got managed_policies %v, expected %v", got, expected)
+	}
+
+	requests := api.Requests("ListManagedPoliciesInPermissionSet")
+
+	if got, expected := len(requests), 2; got != expected {
+		t.Fatalf("got %d ListManagedPoliciesInPermissionSet calls, expected %d", got, expected)
+	}
+
+	if got, expected := requests[1].Body["NextToken"], "page-2"; got != expected {
+		t.Errorf("got NextToken %v, expected %s", got, expected)
+	}
+}
@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+)
+
+func dataSourceAwsSsoPermissionSet() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoPermissionSetRead,
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 32),
+					validation.StringMatch(regexp.MustCompile(`^[\w+=,.@-]+$`), "must match [\\w+=,.@-]"),
+				),
+			},
+			"relay_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"session_duration": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoPermissionSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn := d.Get("instance_arn").(string)
+	name := d.Get("name").(string)
+
+	permissionSetArns, err := finder.PermissionSetArns(ctx, conn, instanceArn)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing SSO Permission Sets for instance (%s): %w", instanceArn, cleanAwsRequestError(err)))
+	}
+
+	var permissionSet *ssoadmin.PermissionSet
+
+	for _, permissionSetArn := range permissionSetArns {
+		output, err := conn.DescribePermissionSetWithContext(ctx, &ssoadmin.DescribePermissionSetInput{
+			InstanceArn:      aws.String(instanceArn),
+			PermissionSetArn: aws.String(permissionSetArn),
+		})
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+		}
+
+		if output == nil || output.PermissionSet == nil {
+			continue
+		}
+
+		if aws.StringValue(output.PermissionSet.Name) == name {
+			permissionSet = output.PermissionSet
+			break
+		}
+	}
+
+	if permissionSet == nil {
+		return diag.Errorf("couldn't find any SSO Permission Sets with name (%s) in instance (%s)", name, instanceArn)
+	}
+
+	arn := aws.StringValue(permissionSet.PermissionSetArn)
+
+	d.SetId(arn)
+	d.Set("arn", arn)
+	d.Set("description", permissionSet.Description)
+	d.Set("instance_arn", instanceArn)
+	d.Set("name", permissionSet.Name)
+	d.Set("relay_state", permissionSet.RelayState)
+	d.Set("session_duration", permissionSet.SessionDuration)
+
+	return nil
+}
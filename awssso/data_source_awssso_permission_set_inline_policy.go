@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsSsoPermissionSetInlinePolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoPermissionSetInlinePolicyRead,
+
+		Schema: map[string]*schema.Schema{
+			"inline_policy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoPermissionSetInlinePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn := d.Get("instance_arn").(string)
+	permissionSetArn := d.Get("permission_set_arn").(string)
+
+	output, err := conn.GetInlinePolicyForPermissionSetWithContext(ctx, &ssoadmin.GetInlinePolicyForPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Inline Policy for SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	var policy string
+
+	// Permission sets without an inline policy return an empty policy.
+	if output != nil {
+		policy, err = normalizePolicyJSON(aws.StringValue(output.InlinePolicy))
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error normalizing Inline Policy for SSO Permission Set (%s): %w", permissionSetArn, err))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s", permissionSetArn, instanceArn))
+	d.Set("inline_policy", policy)
+	d.Set("instance_arn", instanceArn)
+	d.Set("permission_set_arn", permissionSetArn)
+
+	return nil
+}
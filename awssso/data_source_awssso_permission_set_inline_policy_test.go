@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoPermissionSetInlinePolicyRead(t *testing.T) {
+	testCases := []struct {
+		TestName             string
+		Response             map[string]interface{}
+		ExpectedInlinePolicy string
+	}{
+		{
+			TestName: "populated",
+			Response: map[string]interface{}{
+				"InlinePolicy": `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:ListAllMyBuckets", "Resource": "*"}]}`,
+			},
+			ExpectedInlinePolicy: `{"Statement":[{"Action":"s3:ListAllMyBuckets","Effect":"Allow","Resource":"*"}],"Version":"2012-10-17"}`,
+		},
+		{
+			TestName: "empty",
+			Response: map[string]interface{}{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"GetInlinePolicyForPermissionSet": {{Body: testCase.Response}},
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoPermissionSetInlinePolicy().Schema, map[string]interface{}{
+				"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			})
+
+			if diags := dataSourceAwsSsoPermissionSetInlinePolicyRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("error reading data source: %v", diags)
+			}
+
+			if got, expected := len(api.Requests("GetInlinePolicyForPermissionSet")), 1; got != expected {
+				t.Fatalf("got %d GetInlinePolicyForPermissionSet calls, expected %d", got, expected)
+			}
+
+			if got := d.Get("inline_policy").(string); got != testCase.ExpectedInlinePolicy {
+				t.Errorf("got inline_policy %s, expected %s", got, testCase.ExpectedInlinePolicy)
+			}
+
+			if got, expected := d.Id(), "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111"; got != expected {
+				t.Errorf("got ID %s, expected %s", got, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoPermissionSetRead(t *testing.T) {
+	testCases := []struct {
+		TestName                string
+		Name                    string
+		ExpectedError           *regexp.Regexp
+		ExpectedArn             string
+		ExpectedSessionDuration string
+	}{
+		{
+			TestName:                "found on second page",
+			Name:                    "target",
+			ExpectedArn:             "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+			ExpectedSessionDuration: "PT4H",
+		},
+		{
+			TestName:      "not found",
+			Name:          "missing",
+			ExpectedError: regexp.MustCompile(`couldn't find any SSO Permission Sets with name \(missing\)`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ListPermissionSets": {
+					{Body: map[string]interface{}{
+						"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"},
+						"NextToken":      "page-2",
+					}},
+					{Body: map[string]interface{}{
+						"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222"},
+					}},
+				},
+				"DescribePermissionSet": {
+					{Body: map[string]interface{}{
+						"PermissionSet": map[string]interface{}{
+							"Name":             "other",
+							"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+							"SessionDuration":  "PT1H",
+						},
+					}},
+					{Body: map[string]interface{}{
+						"PermissionSet": map[string]interface{}{
+							"Description":      "Target",
+							"Name":             "target",
+							"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+							"RelayState":       "https://console.aws.amazon.com",
+							"SessionDuration":  "PT4H",
+						},
+					}},
+				},
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoPermissionSet().Schema, map[string]interface{}{
+				"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"name":         testCase.Name,
+			})
+
+			diags := dataSourceAwsSsoPermissionSetRead(context.Background(), d, client)
+
+			if !diags.HasError() && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if diags.HasError() && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %v", diags)
+			}
+
+			if diags.HasError() && !testCase.ExpectedError.MatchString(diags[0].Summary) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+			}
+
+			if got, expected := len(api.Requests("ListPermissionSets")), 2; got != expected {
+				t.Errorf("got %d ListPermissionSets calls, expected %d", got, expected)
+			}
+
+			if got := d.Get("arn").(string); got != testCase.ExpectedArn {
+				t.Errorf("got arn %s, expected %s", got, testCase.ExpectedArn)
+			}
+
+			if got := d.Get("session_duration").(string); got != testCase.ExpectedSessionDuration {
+				t.Errorf("got session_duration %s, expected %s", got, testCase.ExpectedSessionDuration)
+			}
+		})
+	}
+}
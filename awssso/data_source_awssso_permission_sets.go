@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+)
+
+func dataSourceAwsSsoPermissionSets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoPermissionSetsRead,
+
+		Schema: map[string]*schema.Schema{
+			"arns": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"name_filter": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"tags": tagsSchema(),
+			"truncated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoPermissionSetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn := d.Get("instance_arn").(string)
+
+	permissionSetArns, truncated, err := finder.PermissionSetArnsWithLimit(ctx, conn, instanceArn, d.Get("max_results").(int))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing SSO Permission Sets for instance (%s): %w", instanceArn, cleanAwsRequestError(err)))
+	}
+
+	// Permission set names are only available from DescribePermissionSet, so
+	// the filter is applied client-side.
+	if v, ok := d.GetOk("name_filter"); ok {
+		nameFilter := regexp.MustCompile(v.(string))
+		var filtered []string
+
+		for _, permissionSetArn := range permissionSetArns {
+			output, err := conn.DescribePermissionSetWithContext(ctx, &ssoadmin.DescribePermissionSetInput{
+				InstanceArn:      aws.String(instanceArn),
+				PermissionSetArn: aws.String(permissionSetArn),
+			})
+
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error reading SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+			}
+
+			if output == nil || output.PermissionSet == nil {
+				continue
+			}
+
+			if nameFilter.MatchString(aws.StringValue(output.PermissionSet.Name)) {
+				filtered = append(filtered, permissionSetArn)
+			}
+		}
+
+		permissionSetArns = filtered
+	}
+
+	// Tags are likewise only available per permission set. Tags ignored by the
+	// provider configuration are dropped from both sides before comparing.
+	if v, ok := d.GetOk("tags"); ok {
+		tagsFilter := ignoreTags(meta.(*AWSClient), keyvaluetags.New(v.(map[string]interface{})))
+		var filtered []string
+
+		for _, permissionSetArn := range permissionSetArns {
+			tags, err := keyvaluetags.SsoadminListTagsWithContext(ctx, conn, permissionSetArn, instanceArn)
+
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error listing tags for SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+			}
+
+			if ignoreTags(meta.(*AWSClient), tags).ContainsAll(tagsFilter) {
+				filtered = append(filtered, permissionSetArn)
+			}
+		}
+
+		permissionSetArns = filtered
+	}
+
+	d.SetId(instanceArn)
+	d.Set("instance_arn", instanceArn)
+	d.Set("truncated", truncated)
+
+	if err := d.Set("arns", permissionSetArns); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting arns: %w", err))
+	}
+
+	return nil
+}
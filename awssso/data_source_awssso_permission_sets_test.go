@@ -0,0 +1,198 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoPermissionSetsRead(t *testing.T) {
+	testCases := []struct {
+		TestName              string
+		NameFilter            string
+		ExpectedArns          []string
+		ExpectedDescribeCalls int
+	}{
+		{
+			TestName: "all pages",
+			ExpectedArns: []string{
+				"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+				"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+			},
+		},
+		{
+			TestName:   "name filter",
+			NameFilter: "^Admin",
+			ExpectedArns: []string{
+				"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+			},
+			ExpectedDescribeCalls: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ListPermissionSets": {
+					{Body: map[string]interface{}{
+						"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"},
+						"NextToken":      "page-2",
+					}},
+					{Body: map[string]interface{}{
+						"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222"},
+					}},
+				},
+				"DescribePermissionSet": {
+					{Body: map[string]interface{}{
+						"PermissionSet": map[string]interface{}{
+							"Name":             "ReadOnly",
+							"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+						},
+					}},
+					{Body: map[string]interface{}{
+						"PermissionSet": map[string]interface{}{
+							"Name":             "AdministratorAccess",
+							"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+						},
+					}},
+				},
+			})
+
+			raw := map[string]interface{}{
+				"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+			}
+
+			if testCase.NameFilter != "" {
+				raw["name_filter"] = testCase.NameFilter
+			}
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoPermissionSets().Schema, raw)
+
+			if diags := dataSourceAwsSsoPermissionSetsRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("error reading data source: %v", diags)
+			}
+
+			var arns []string
+			for _, v := range d.Get("arns").(*schema.Set).List() {
+				arns = append(arns, v.(string))
+			}
+			sort.Strings(arns)
+
+			if !reflect.DeepEqual(arns, testCase.ExpectedArns) {
+				t.Errorf("got arns %v, expected %v", arns, testCase.ExpectedArns)
+			}
+
+			if got, expected := len(api.Requests("ListPermissionSets")), 2; got != expected {
+				t.Errorf("got %d ListPermissionSets calls, expected %d", got, expected)
+			}
+
+			if got, expected := len(api.Requests("DescribePermissionSet")), testCase.ExpectedDescribeCalls; got != expected {
+				t.Errorf("got %d DescribePermissionSet calls, expected %d", got, expected)
+			}
+		})
+	}
+}
+
+func TestDataSourceAwsSsoPermissionSetsRead_maxResults(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ListPermissionSets": {
+			{Body: map[string]interface{}{
+				"PermissionSets": []interface{}{
+					"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+					"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+				},
+				"NextToken": "page-2",
+			}},
+			{Body: map[string]interface{}{
+				"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-3333333333333333"},
+			}},
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceAwsSsoPermissionSets().Schema, map[string]interface{}{
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"max_results":  2,
+	})
+
+	if diags := dataSourceAwsSsoPermissionSetsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading data source: %v", diags)
+	}
+
+	requests := api.Requests("ListPermissionSets")
+
+	if got, expected := len(requests), 2; got != expected {
+		t.Fatalf("got %d ListPermissionSets calls, expected %d", got, expected)
+	}
+
+	if got, expected := requests[0].Body["MaxResults"], float64(2); got != expected {
+		t.Errorf("got MaxResults %v, expected %v", got, expected)
+	}
+
+	if got, expected := d.Get("arns").(*schema.Set).Len(), 2; got != expected {
+		t.Errorf("got %d arns, expected %d", got, expected)
+	}
+
+	if !d.Get("truncated").(bool) {
+		t.Error("expected truncated to be true")
+	}
+}
+
+func TestDataSourceAwsSsoPermissionSetsRead_tags(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ListPermissionSets": {
+			{Body: map[string]interface{}{
+				"PermissionSets": []interface{}{
+					"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+					"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+					"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-3333333333333333",
+				},
+			}},
+		},
+		"ListTagsForResource": {
+			{Body: map[string]interface{}{"Tags": []interface{}{
+				map[string]interface{}{"Key": "Team", "Value": "Security"},
+			}}},
+			{Body: map[string]interface{}{"Tags": []interface{}{
+				map[string]interface{}{"Key": "Environment", "Value": "production"},
+				map[string]interface{}{"Key": "Team", "Value": "Platform"},
+			}}},
+			{Body: map[string]interface{}{"Tags": []interface{}{}}},
+		},
+	})
+	client.IgnoreTagsConfig = &keyvaluetags.IgnoreConfig{
+		Keys: keyvaluetags.New([]interface{}{"Owner"}),
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceAwsSsoPermissionSets().Schema, map[string]interface{}{
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"tags": map[string]interface{}{
+			"Owner": "platform-team",
+			"Team":  "Platform",
+		},
+	})
+
+	if diags := dataSourceAwsSsoPermissionSetsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading data source: %v", diags)
+	}
+
+	expected := []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222"}
+
+	if got := d.Get("arns").(*schema.Set).List(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got arns %v, expected %v", got, expected)
+	}
+
+	requests := api.Requests("ListTagsForResource")
+
+	if got, expected := len(requests), 3; got != expected {
+		t.Fatalf("got %d ListTagsForResource calls, expected %d", got, expected)
+	}
+
+	if got, expected := requests[1].Body["InstanceArn"], "arn:aws:sso:::instance/ssoins-1111111111111111"; got != expected {
+		t.Errorf("got InstanceArn %v, expected %v", got, expected)
+	}
+}
@@ -0,0 +1,59 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+)
+
+func dataSourceAwsSsoProvisionedPermissionSets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoProvisionedPermissionSetsRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"arns": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoProvisionedPermissionSetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	accountID := d.Get("account_id").(string)
+	instanceArn := d.Get("instance_arn").(string)
+
+	permissionSetArns, err := finder.PermissionSetArnsProvisionedToAccount(ctx, conn, accountID, instanceArn, ssoadmin.ProvisioningStatusLatestPermissionSetProvisioned)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing SSO Permission Sets provisioned to account (%s) for instance (%s): %w", accountID, instanceArn, cleanAwsRequestError(err)))
+	}
+
+	d.SetId(strings.Join([]string{accountID, instanceArn}, ","))
+	d.Set("account_id", accountID)
+	d.Set("instance_arn", instanceArn)
+
+	if err := d.Set("arns", permissionSetArns); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting arns: %w", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoProvisionedPermissionSetsRead(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Responses     []mockapi.Response
+		ExpectedArns  []string
+		ExpectedCalls int
+	}{
+		{
+			TestName: "all pages",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{
+					"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"},
+					"NextToken":      "page-2",
+				}},
+				{Body: map[string]interface{}{
+					"PermissionSets": []interface{}{"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222"},
+				}},
+			},
+			ExpectedArns: []string{
+				"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+				"arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-2222222222222222",
+			},
+			ExpectedCalls: 2,
+		},
+		{
+			TestName: "empty",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{"PermissionSets": []interface{}{}}},
+			},
+			ExpectedCalls: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ListPermissionSetsProvisionedToAccount": testCase.Responses,
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoProvisionedPermissionSets().Schema, map[string]interface{}{
+				"account_id":   "123456789012",
+				"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+			})
+
+			if diags := dataSourceAwsSsoProvisionedPermissionSetsRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("error reading data source: %v", diags)
+			}
+
+			requests := api.Requests("ListPermissionSetsProvisionedToAccount")
+
+			if got, expected := len(requests), testCase.ExpectedCalls; got != expected {
+				t.Fatalf("got %d ListPermissionSetsProvisionedToAccount calls, expected %d", got, expected)
+			}
+
+			for k, expected := range map[string]string{
+				"AccountId":          "123456789012",
+				"ProvisioningStatus": ssoadmin.ProvisioningStatusLatestPermissionSetProvisioned,
+			} {
+				if got := requests[0].Body[k]; got != expected {
+					t.Errorf("got %s %v, expected %s", k, got, expected)
+				}
+			}
+
+			var arns []string
+			for _, v := range d.Get("arns").(*schema.Set).List() {
+				arns = append(arns, v.(string))
+			}
+
+			sort.Strings(arns)
+
+			if !reflect.DeepEqual(arns, testCase.ExpectedArns) {
+				t.Errorf("got arns %v, expected %v", arns, testCase.ExpectedArns)
+			}
+		})
+	}
+}
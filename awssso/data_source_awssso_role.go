@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -8,13 +9,14 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
 )
 
 func dataSourceAwsSsoRole() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceAwsSsoRoleRead,
+		ReadContext: dataSourceAwsSsoRoleRead,
 
 		Schema: map[string]*schema.Schema{
 			"arn": {
@@ -62,9 +64,8 @@ func dataSourceAwsSsoRole() *schema.Resource {
 	}
 }
 
-func dataSourceAwsSsoRoleRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceAwsSsoRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	iamconn := meta.(*AWSClient).iamconn
-	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
 
 	permissionSetName := d.Get("permission_set_name").(string)
 	pathPrefix := aws.String("/aws-reserved/sso.amazonaws.com/")
@@ -77,7 +78,8 @@ func dataSourceAwsSsoRoleRead(d *schema.ResourceData, meta interface{}) error {
 
 	roles := []*iam.Role{}
 
-	err := iamconn.ListRolesPages(
+	err := iamconn.ListRolesPagesWithContext(
+		ctx,
 		input,
 		func(page *iam.ListRolesOutput, lastPage bool) bool {
 			for _, role := range page.Roles {
@@ -89,21 +91,21 @@ func dataSourceAwsSsoRoleRead(d *schema.ResourceData, meta interface{}) error {
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("error reading roles with path prefix (%s): %w", aws.StringValue(pathPrefix), err)
+		return diag.FromErr(fmt.Errorf("error reading roles with path prefix (%s): %w", aws.StringValue(pathPrefix), err))
 	}
 
 	if len(roles) > 1 {
-		return fmt.Errorf("found too many SSO roles (%d) matching the permission set name", len(roles))
+		return diag.Errorf("found too many SSO roles (%d) matching the permission set name", len(roles))
 	}
 	if len(roles) == 0 {
-		return fmt.Errorf("couldn't find any SSO roles matching the permission set name")
+		return diag.Errorf("couldn't find any SSO roles matching the permission set name")
 	}
 
 	role := roles[0]
 
 	d.Set("arn", role.Arn)
 	if err := d.Set("create_date", role.CreateDate.Format(time.RFC3339)); err != nil {
-		return fmt.Errorf("error setting create_date: %w", err)
+		return diag.FromErr(fmt.Errorf("error setting create_date: %w", err))
 	}
 	d.Set("description", role.Description)
 	d.Set("max_session_duration", role.MaxSessionDuration)
@@ -114,14 +116,16 @@ func dataSourceAwsSsoRoleRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("permissions_boundary", role.PermissionsBoundary.PermissionsBoundaryArn)
 	}
 	d.Set("unique_id", role.RoleId)
-	d.Set("tags", keyvaluetags.IamKeyValueTags(role.Tags).IgnoreAws().IgnoreConfig(ignoreTagsConfig).Map())
+	if err := setTagsComputed(d, "tags", keyvaluetags.IamKeyValueTags(role.Tags), meta); err != nil {
+		return diag.FromErr(err)
+	}
 
 	assumRolePolicy, err := url.QueryUnescape(aws.StringValue(role.AssumeRolePolicyDocument))
 	if err != nil {
-		return fmt.Errorf("error parsing assume role policy document: %w", err)
+		return diag.FromErr(fmt.Errorf("error parsing assume role policy document: %w", err))
 	}
 	if err := d.Set("assume_role_policy", assumRolePolicy); err != nil {
-		return fmt.Errorf("error setting assume_role_policy: %w", err)
+		return diag.FromErr(fmt.Errorf("error setting assume_role_policy: %w", err))
 	}
 
 	d.SetId(aws.StringValue(role.RoleName))
@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+)
+
+func dataSourceAwsSsoTags() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoTagsRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"resource_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateArn,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoTagsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn := d.Get("instance_arn").(string)
+	resourceArn := d.Get("resource_arn").(string)
+
+	tags, err := keyvaluetags.SsoadminListTagsWithContext(ctx, conn, resourceArn, instanceArn)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing tags for SSO resource (%s): %w", resourceArn, cleanAwsRequestError(err)))
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s", resourceArn, instanceArn))
+	d.Set("instance_arn", instanceArn)
+	d.Set("resource_arn", resourceArn)
+
+	if err := setTagsComputed(d, "tags", tags, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoTagsRead(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ListTagsForResource": {{Body: map[string]interface{}{"Tags": []interface{}{
+			map[string]interface{}{"Key": "aws:cloudformation:stack-name", "Value": "stack"},
+			map[string]interface{}{"Key": "Ignored", "Value": "value"},
+			map[string]interface{}{"Key": "ignored:team", "Value": "value"},
+			map[string]interface{}{"Key": "Team", "Value": "Platform"},
+		}}}},
+	})
+	client.IgnoreTagsConfig = &keyvaluetags.IgnoreConfig{
+		Keys:        keyvaluetags.New([]interface{}{"Ignored"}),
+		KeyPrefixes: keyvaluetags.New([]interface{}{"ignored:"}),
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceAwsSsoTags().Schema, map[string]interface{}{
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"resource_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	})
+
+	if diags := dataSourceAwsSsoTagsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading data source: %v", diags)
+	}
+
+	requests := api.Requests("ListTagsForResource")
+
+	if got, expected := len(requests), 1; got != expected {
+		t.Fatalf("got %d ListTagsForResource calls, expected %d", got, expected)
+	}
+
+	if got, expected := requests[0].Body["ResourceArn"], "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"; got != expected {
+		t.Errorf("got ResourceArn %v, expected %v", got, expected)
+	}
+
+	expected := map[string]interface{}{"Team": "Platform"}
+
+	if got := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got tags %v, expected %v", got, expected)
+	}
+
+	if got, expected := d.Id(), "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+}
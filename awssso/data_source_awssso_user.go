@@ -0,0 +1,191 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/identitystore/finder"
+)
+
+func dataSourceAwsSsoUser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAwsSsoUserRead,
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"emails": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"primary": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"external_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issuer": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"identity_store_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 36),
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+		},
+	}
+}
+
+func dataSourceAwsSsoUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	identityStoreID := d.Get("identity_store_id").(string)
+	userName := d.Get("user_name").(string)
+
+	users, err := finder.UsersByUserName(ctx, conn, identityStoreID, userName)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Identity Store (%s) users: %w", identityStoreID, cleanAwsRequestError(err)))
+	}
+
+	if len(users) == 0 {
+		return diag.Errorf("couldn't find any Identity Store users with user name (%s)", userName)
+	}
+
+	if len(users) > 1 {
+		return diag.Errorf("found too many Identity Store users (%d) with user name (%s)", len(users), userName)
+	}
+
+	userID := aws.StringValue(users[0].UserId)
+
+	user, err := conn.DescribeUserWithContext(ctx, &identitystore.DescribeUserInput{
+		IdentityStoreId: aws.String(identityStoreID),
+		UserId:          aws.String(userID),
+	})
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Identity Store (%s) user (%s): %w", identityStoreID, userID, cleanAwsRequestError(err)))
+	}
+
+	if user == nil {
+		return diag.Errorf("error reading Identity Store (%s) user (%s): empty output", identityStoreID, userID)
+	}
+
+	d.SetId(userID)
+	d.Set("display_name", user.DisplayName)
+	d.Set("email", primaryIdentityStoreEmail(user.Emails))
+
+	if err := d.Set("emails", flattenIdentityStoreEmails(user.Emails)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting emails: %w", err))
+	}
+
+	if err := d.Set("external_ids", flattenIdentityStoreExternalIds(user.ExternalIds)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting external_ids: %w", err))
+	}
+
+	d.Set("identity_store_id", identityStoreID)
+	d.Set("user_id", user.UserId)
+	d.Set("user_name", user.UserName)
+
+	return nil
+}
+
+func flattenIdentityStoreEmails(emails []*identitystore.Email) []interface{} {
+	var results []interface{}
+
+	for _, email := range emails {
+		if email == nil {
+			continue
+		}
+
+		results = append(results, map[string]interface{}{
+			"primary": aws.BoolValue(email.Primary),
+			"type":    aws.StringValue(email.Type),
+			"value":   aws.StringValue(email.Value),
+		})
+	}
+
+	return results
+}
+
+func flattenIdentityStoreExternalIds(externalIds []*identitystore.ExternalId) []interface{} {
+	var results []interface{}
+
+	for _, externalID := range externalIds {
+		if externalID == nil {
+			continue
+		}
+
+		results = append(results, map[string]interface{}{
+			"id":     aws.StringValue(externalID.Id),
+			"issuer": aws.StringValue(externalID.Issuer),
+		})
+	}
+
+	return results
+}
+
+// primaryIdentityStoreEmail returns the address of the primary email, falling
+// back to the first email when none is flagged as primary.
+func primaryIdentityStoreEmail(emails []*identitystore.Email) string {
+	var first string
+
+	for _, email := range emails {
+		if email == nil {
+			continue
+		}
+
+		if aws.BoolValue(email.Primary) {
+			return aws.StringValue(email.Value)
+		}
+
+		if first == "" {
+			first = aws.StringValue(email.Value)
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,180 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestDataSourceAwsSsoUserRead(t *testing.T) {
+	testCases := []struct {
+		TestName            string
+		Users               []map[string]interface{}
+		ExpectedError       *regexp.Regexp
+		ExpectedUserID      string
+		ExpectedDisplayName string
+		ExpectedEmail       string
+	}{
+		{
+			TestName:      "not found",
+			Users:         []map[string]interface{}{},
+			ExpectedError: regexp.MustCompile(`couldn't find any Identity Store users`),
+		},
+		{
+			TestName: "exactly one",
+			Users: []map[string]interface{}{
+				{
+					"UserId":          "11111111-1111-1111-1111-111111111111",
+					"UserName":        "jdoe",
+					"DisplayName":     "J. Doe",
+					"IdentityStoreId": "d-1111111111",
+					"Emails": []map[string]interface{}{
+						{"Value": "jdoe@example.org", "Primary": false},
+						{"Value": "jdoe@example.com", "Primary": true},
+					},
+				},
+			},
+			ExpectedUserID:      "11111111-1111-1111-1111-111111111111",
+			ExpectedDisplayName: "J. Doe",
+			ExpectedEmail:       "jdoe@example.com",
+		},
+		{
+			TestName: "multiple",
+			Users: []map[string]interface{}{
+				{"UserId": "11111111-1111-1111-1111-111111111111", "UserName": "jdoe", "IdentityStoreId": "d-1111111111"},
+				{"UserId": "22222222-2222-2222-2222-222222222222", "UserName": "jdoe", "IdentityStoreId": "d-1111111111"},
+			},
+			ExpectedError: regexp.MustCompile(`found too many Identity Store users \(2\)`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			var user map[string]interface{}
+
+			if len(testCase.Users) > 0 {
+				user = testCase.Users[0]
+			}
+
+			client, _ := testMockClient(t, map[string][]mockapi.Response{
+				"DescribeUser": {{Body: user}},
+				"ListUsers":    {{Body: map[string]interface{}{"Users": testCase.Users}}},
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoUser().Schema, map[string]interface{}{
+				"identity_store_id": "d-1111111111",
+				"user_name":         "jdoe",
+			})
+
+			diags := dataSourceAwsSsoUserRead(context.Background(), d, client)
+
+			if !diags.HasError() && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if diags.HasError() && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %v", diags)
+			}
+
+			if diags.HasError() && !testCase.ExpectedError.MatchString(diags[0].Summary) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+			}
+
+			if got := d.Get("user_id").(string); got != testCase.ExpectedUserID {
+				t.Errorf("got user_id %s, expected %s", got, testCase.ExpectedUserID)
+			}
+
+			if got := d.Get("display_name").(string); got != testCase.ExpectedDisplayName {
+				t.Errorf("got display_name %s, expected %s", got, testCase.ExpectedDisplayName)
+			}
+
+			if got := d.Get("email").(string); got != testCase.ExpectedEmail {
+				t.Errorf("got email %s, expected %s", got, testCase.ExpectedEmail)
+			}
+		})
+	}
+}
+
+func TestDataSourceAwsSsoUserRead_nestedAttributes(t *testing.T) {
+	testCases := []struct {
+		TestName            string
+		User                map[string]interface{}
+		ExpectedEmails      []interface{}
+		ExpectedExternalIds []interface{}
+	}{
+		{
+			TestName: "emails and external IDs",
+			User: map[string]interface{}{
+				"UserId":          "11111111-1111-1111-1111-111111111111",
+				"UserName":        "jdoe",
+				"IdentityStoreId": "d-1111111111",
+				"Emails": []map[string]interface{}{
+					{"Value": "jdoe@example.com", "Type": "work", "Primary": true},
+					{"Value": "jdoe@example.org", "Type": "home", "Primary": false},
+				},
+				"ExternalIds": []map[string]interface{}{
+					{"Issuer": "https://scim.example.com", "Id": "00u1111111"},
+				},
+			},
+			ExpectedEmails: []interface{}{
+				map[string]interface{}{"primary": true, "type": "work", "value": "jdoe@example.com"},
+				map[string]interface{}{"primary": false, "type": "home", "value": "jdoe@example.org"},
+			},
+			ExpectedExternalIds: []interface{}{
+				map[string]interface{}{"id": "00u1111111", "issuer": "https://scim.example.com"},
+			},
+		},
+		{
+			TestName: "no emails",
+			User: map[string]interface{}{
+				"UserId":          "11111111-1111-1111-1111-111111111111",
+				"UserName":        "jdoe",
+				"IdentityStoreId": "d-1111111111",
+			},
+			ExpectedEmails:      []interface{}{},
+			ExpectedExternalIds: []interface{}{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"DescribeUser": {{Body: testCase.User}},
+				"ListUsers": {{Body: map[string]interface{}{"Users": []map[string]interface{}{
+					{"UserId": "11111111-1111-1111-1111-111111111111", "UserName": "jdoe", "IdentityStoreId": "d-1111111111"},
+				}}}},
+			})
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsSsoUser().Schema, map[string]interface{}{
+				"identity_store_id": "d-1111111111",
+				"user_name":         "jdoe",
+			})
+
+			if diags := dataSourceAwsSsoUserRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("got unexpected error: %v", diags)
+			}
+
+			requests := api.Requests("DescribeUser")
+
+			if got, expected := len(requests), 1; got != expected {
+				t.Fatalf("got %d DescribeUser calls, expected %d", got, expected)
+			}
+
+			if got, expected := requests[0].Body["UserId"], "11111111-1111-1111-1111-111111111111"; got != expected {
+				t.Errorf("got UserId %v, expected %s", got, expected)
+			}
+
+			if got, expected := d.Get("emails").([]interface{}), testCase.ExpectedEmails; !reflect.DeepEqual(got, expected) {
+				t.Errorf("got emails %v, expected %v", got, expected)
+			}
+
+			if got, expected := d.Get("external_ids").([]interface{}), testCase.ExpectedExternalIds; !reflect.DeepEqual(got, expected) {
+				t.Errorf("got external_ids %v, expected %v", got, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// mockapi contains a mocked AWS JSON protocol API for unit testing.
+package mockapi
@@ -0,0 +1,141 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/mitchellh/go-testing-interface"
+)
+
+// Response is a canned response for a single mocked API call.
+// When ErrorCode is set, an error response with that code is returned.
+type Response struct {
+	Body       interface{}
+	ErrorCode  string
+	StatusCode int
+}
+
+// Request is a request received by the mocked API.
+type Request struct {
+	Operation string
+	Body      map[string]interface{}
+}
+
+// API serves canned JSON protocol responses keyed by operation name
+// (e.g. ListInstances). Each call to an operation consumes the next response
+// in its list, with the last response repeated once the list is exhausted.
+type API struct {
+	URL string
+
+	mu        sync.Mutex
+	responses map[string][]Response
+	requests  []Request
+}
+
+// New starts a mocked API serving the given responses, which is shut down
+// when the test completes.
+func New(t testing.T, responses map[string][]Response) *API {
+	t.Helper()
+
+	api := &API{responses: responses}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	api.URL = server.URL
+
+	return api
+}
+
+// Session returns an AWS session with static credentials and retries
+// disabled, with all requests sent to the mocked API.
+func (api *API) Session() *session.Session {
+	return session.Must(session.NewSession(&aws.Config{
+		Credentials: credentials.NewStaticCredentials("StaticAccessKey", "StaticSecretKey", ""),
+		Endpoint:    aws.String(api.URL),
+		MaxRetries:  aws.Int(0),
+		Region:      aws.String("us-east-1"),
+	}))
+}
+
+// Requests returns the requests received for the given operation.
+func (api *API) Requests(operation string) []Request {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	var requests []Request
+	for _, request := range api.requests {
+		if request.Operation == operation {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
+// Operations returns the operation names of all requests received, in the
+// order they were received.
+func (api *API) Operations() []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	operations := make([]string, 0, len(api.requests))
+	for _, request := range api.requests {
+		operations = append(operations, request.Operation)
+	}
+
+	return operations
+}
+
+func (api *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	target := r.Header.Get("X-Amz-Target")
+	operation := target[strings.LastIndex(target, ".")+1:]
+
+	body := make(map[string]interface{})
+	if raw, err := ioutil.ReadAll(r.Body); err == nil && len(raw) > 0 {
+		_ = json.Unmarshal(raw, &body)
+	}
+
+	api.mu.Lock()
+	api.requests = append(api.requests, Request{Operation: operation, Body: body})
+	responses, ok := api.responses[operation]
+	var response Response
+	if ok && len(responses) > 0 {
+		response = responses[0]
+		if len(responses) > 1 {
+			api.responses[operation] = responses[1:]
+		}
+	}
+	api.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+
+	if !ok {
+		w.WriteHeader(http.StatusNotImplemented)
+		fmt.Fprintf(w, `{"__type":"NotImplemented","message":"no mocked response for %s"}`, operation)
+		return
+	}
+
+	if response.ErrorCode != "" {
+		statusCode := response.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusBadRequest
+		}
+		w.WriteHeader(statusCode)
+		fmt.Fprintf(w, `{"__type":%q,"message":"mocked %s"}`, response.ErrorCode, response.ErrorCode)
+		return
+	}
+
+	if response.Body == nil {
+		response.Body = map[string]interface{}{}
+	}
+
+	_ = json.NewEncoder(w).Encode(response.Body)
+}
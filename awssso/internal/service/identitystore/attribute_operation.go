@@ -0,0 +1,94 @@
+package identitystore
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+	"github.com/aws/aws-sdk-go/service/identitystore"
+)
+
+// AttributeOperation mirrors identitystore.AttributeOperation with the
+// AttributeValue member, which the SDK omits because it is modelled as a
+// document type. AttributeValue may be any value encoding/json can marshal,
+// such as a string or a list of maps. A nil AttributeValue removes the
+// attribute.
+type AttributeOperation struct {
+	_ struct{} `type:"structure"`
+
+	AttributePath *string `min:"1" type:"string" required:"true"`
+
+	AttributeValue interface{} `json:",omitempty"`
+}
+
+// UpdateGroupInput mirrors identitystore.UpdateGroupInput using AttributeOperation.
+type UpdateGroupInput struct {
+	_ struct{} `type:"structure"`
+
+	GroupId *string `min:"1" type:"string" required:"true"`
+
+	IdentityStoreId *string `min:"1" type:"string" required:"true"`
+
+	Operations []*AttributeOperation `min:"1" type:"list" required:"true"`
+}
+
+// UpdateGroup calls the UpdateGroup API with attribute values included.
+func UpdateGroup(ctx context.Context, conn *identitystore.IdentityStore, input *UpdateGroupInput) (*identitystore.UpdateGroupOutput, error) {
+	output := &identitystore.UpdateGroupOutput{}
+
+	return output, send(ctx, conn, "UpdateGroup", input, output)
+}
+
+// UpdateUserInput mirrors identitystore.UpdateUserInput using AttributeOperation.
+type UpdateUserInput struct {
+	_ struct{} `type:"structure"`
+
+	IdentityStoreId *string `min:"1" type:"string" required:"true"`
+
+	Operations []*AttributeOperation `min:"1" type:"list" required:"true"`
+
+	UserId *string `min:"1" type:"string" required:"true"`
+}
+
+// UpdateUser calls the UpdateUser API with attribute values included.
+func UpdateUser(ctx context.Context, conn *identitystore.IdentityStore, input *UpdateUserInput) (*identitystore.UpdateUserOutput, error) {
+	output := &identitystore.UpdateUserOutput{}
+
+	return output, send(ctx, conn, "UpdateUser", input, output)
+}
+
+// send sends an operation whose input has document type attribute values,
+// which the SDK JSON protocol cannot encode, using encoding/json for the body.
+func send(ctx context.Context, conn *identitystore.IdentityStore, name string, input, output interface{}) error {
+	op := &request.Operation{
+		Name:       name,
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+
+	req := conn.NewRequest(op, input, output)
+	req.SetContext(ctx)
+	req.Handlers.Build.Swap(jsonrpc.BuildHandler.Name, request.NamedHandler{
+		Name: "tfidentitystore.BuildHandler",
+		Fn:   build,
+	})
+
+	return req.Send()
+}
+
+// build encodes the request parameters with encoding/json and sets the JSON
+// RPC headers the SDK protocol handler would.
+func build(r *request.Request) {
+	body, err := json.Marshal(r.Params)
+
+	if err != nil {
+		r.Error = awserr.New(request.ErrCodeSerialization, "failed encoding JSON RPC request", err)
+		return
+	}
+
+	r.SetBufferBody(body)
+	r.HTTPRequest.Header.Set("X-Amz-Target", r.ClientInfo.TargetPrefix+"."+r.Operation.Name)
+	r.HTTPRequest.Header.Set("Content-Type", "application/x-amz-json-"+r.ClientInfo.JSONVersion)
+}
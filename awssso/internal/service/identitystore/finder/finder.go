@@ -0,0 +1,104 @@
+package finder
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/identitystore"
+)
+
+// GroupsByDisplayName returns the groups within an identity store whose
+// display name matches exactly.
+func GroupsByDisplayName(ctx context.Context, conn *identitystore.IdentityStore, identityStoreID, displayName string) ([]*identitystore.Group, error) {
+	input := &identitystore.ListGroupsInput{
+		IdentityStoreId: aws.String(identityStoreID),
+		Filters: []*identitystore.Filter{
+			{
+				AttributePath:  aws.String("DisplayName"),
+				AttributeValue: aws.String(displayName),
+			},
+		},
+	}
+
+	var results []*identitystore.Group
+
+	err := conn.ListGroupsPagesWithContext(ctx, input, func(page *identitystore.ListGroupsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, group := range page.Groups {
+			if group == nil {
+				continue
+			}
+
+			results = append(results, group)
+		}
+
+		return !lastPage
+	})
+
+	return results, err
+}
+
+// UsersByUserName returns the users within an identity store whose user name
+// matches exactly.
+func UsersByUserName(ctx context.Context, conn *identitystore.IdentityStore, identityStoreID, userName string) ([]*identitystore.User, error) {
+	input := &identitystore.ListUsersInput{
+		IdentityStoreId: aws.String(identityStoreID),
+		Filters: []*identitystore.Filter{
+			{
+				AttributePath:  aws.String("UserName"),
+				AttributeValue: aws.String(userName),
+			},
+		},
+	}
+
+	var results []*identitystore.User
+
+	err := conn.ListUsersPagesWithContext(ctx, input, func(page *identitystore.ListUsersOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, user := range page.Users {
+			if user == nil {
+				continue
+			}
+
+			results = append(results, user)
+		}
+
+		return !lastPage
+	})
+
+	return results, err
+}
+
+// GroupMemberships returns the memberships of a group within an identity store.
+func GroupMemberships(ctx context.Context, conn *identitystore.IdentityStore, identityStoreID, groupID string) ([]*identitystore.GroupMembership, error) {
+	input := &identitystore.ListGroupMembershipsInput{
+		GroupId:         aws.String(groupID),
+		IdentityStoreId: aws.String(identityStoreID),
+	}
+
+	var results []*identitystore.GroupMembership
+
+	err := conn.ListGroupMembershipsPagesWithContext(ctx, input, func(page *identitystore.ListGroupMembershipsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, membership := range page.GroupMemberships {
+			if membership == nil {
+				continue
+			}
+
+			results = append(results, membership)
+		}
+
+		return !lastPage
+	})
+
+	return results, err
+}
@@ -0,0 +1,11 @@
+package waiter
+
+import (
+	"time"
+)
+
+const (
+	// Maximum amount of time to wait for a newly created group to be found, as
+	// the Identity Store API is eventually consistent
+	GroupPropagationTimeout = 30 * time.Second
+)
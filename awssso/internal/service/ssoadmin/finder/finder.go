@@ -0,0 +1,304 @@
+package finder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+)
+
+// Instances returns the SSO instances visible to the caller.
+func Instances(ctx context.Context, conn *ssoadmin.SSOAdmin) ([]*ssoadmin.InstanceMetadata, error) {
+	input := &ssoadmin.ListInstancesInput{}
+
+	var results []*ssoadmin.InstanceMetadata
+
+	err := conn.ListInstancesPagesWithContext(ctx, input, func(page *ssoadmin.ListInstancesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, instance := range page.Instances {
+			if instance == nil {
+				continue
+			}
+
+			results = append(results, instance)
+		}
+
+		return !lastPage
+	})
+
+	return results, err
+}
+
+// PermissionSetArns returns the ARNs of all permission sets within a specified SSO instance.
+func PermissionSetArns(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn string) ([]string, error) {
+	results, _, err := PermissionSetArnsWithLimit(ctx, conn, instanceArn, 0)
+
+	return results, err
+}
+
+// listLimiter bounds the results collected from a paginated list operation.
+type listLimiter struct {
+	// limit is the maximum number of results, or 0 for no maximum.
+	limit     int
+	count     int
+	truncated bool
+}
+
+// add returns whether another result fits within the limit. A result beyond
+// the limit marks the listing as truncated, so that reaching the limit exactly
+// on the last result is not reported as truncated.
+func (l *listLimiter) add() bool {
+	if l.limit > 0 && l.count >= l.limit {
+		l.truncated = true
+		return false
+	}
+
+	l.count++
+
+	return true
+}
+
+// maxResults returns the page size to request, which is only lowered below
+// the API maximum of 100 when the limit is smaller.
+func (l *listLimiter) maxResults() *int64 {
+	if l.limit > 0 && l.limit < 100 {
+		return aws.Int64(int64(l.limit))
+	}
+
+	return nil
+}
+
+// PermissionSetArnsWithLimit returns the ARNs of at most limit permission sets within a specified
+// SSO instance, or of all permission sets if limit is 0, and whether more permission sets exist.
+func PermissionSetArnsWithLimit(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn string, limit int) ([]string, bool, error) {
+	limiter := &listLimiter{limit: limit}
+
+	input := &ssoadmin.ListPermissionSetsInput{
+		InstanceArn: aws.String(instanceArn),
+		MaxResults:  limiter.maxResults(),
+	}
+
+	var results []string
+
+	err := conn.ListPermissionSetsPagesWithContext(ctx, input, func(page *ssoadmin.ListPermissionSetsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, permissionSetArn := range page.PermissionSets {
+			if permissionSetArn == nil {
+				continue
+			}
+
+			if !limiter.add() {
+				return false
+			}
+
+			results = append(results, aws.StringValue(permissionSetArn))
+		}
+
+		return !lastPage
+	})
+
+	return results, limiter.truncated, err
+}
+
+// PermissionSetArnsProvisionedToAccount returns the ARNs of all permission sets provisioned
+// to an account within a specified SSO instance. An empty provisioningStatus includes
+// permission sets whether or not their latest version is provisioned.
+func PermissionSetArnsProvisionedToAccount(ctx context.Context, conn *ssoadmin.SSOAdmin, accountID, instanceArn, provisioningStatus string) ([]string, error) {
+	input := &ssoadmin.ListPermissionSetsProvisionedToAccountInput{
+		AccountId:   aws.String(accountID),
+		InstanceArn: aws.String(instanceArn),
+	}
+
+	if provisioningStatus != "" {
+		input.ProvisioningStatus = aws.String(provisioningStatus)
+	}
+
+	var results []string
+
+	err := conn.ListPermissionSetsProvisionedToAccountPagesWithContext(ctx, input, func(page *ssoadmin.ListPermissionSetsProvisionedToAccountOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, permissionSetArn := range page.PermissionSets {
+			if permissionSetArn == nil {
+				continue
+			}
+
+			results = append(results, aws.StringValue(permissionSetArn))
+		}
+
+		return !lastPage
+	})
+
+	return results, err
+}
+
+// accountAssignmentsMaxPages caps the number of ListAccountAssignments pages
+// followed by AccountAssignments.
+const accountAssignmentsMaxPages = 1000
+
+// AccountAssignments returns the account assignments matching input across all pages,
+// stopping with an error after accountAssignmentsMaxPages.
+func AccountAssignments(ctx context.Context, conn *ssoadmin.SSOAdmin, input *ssoadmin.ListAccountAssignmentsInput) ([]*ssoadmin.AccountAssignment, error) {
+	results, _, err := AccountAssignmentsWithLimit(ctx, conn, input, 0)
+
+	return results, err
+}
+
+// AccountAssignmentsWithLimit returns at most limit account assignments matching input, or all
+// of them if limit is 0, and whether more account assignments exist. It stops with an error
+// after accountAssignmentsMaxPages.
+func AccountAssignmentsWithLimit(ctx context.Context, conn *ssoadmin.SSOAdmin, input *ssoadmin.ListAccountAssignmentsInput, limit int) ([]*ssoadmin.AccountAssignment, bool, error) {
+	limiter := &listLimiter{limit: limit}
+
+	if v := limiter.maxResults(); v != nil {
+		input.MaxResults = v
+	}
+
+	var results []*ssoadmin.AccountAssignment
+	var exceeded bool
+	pages := 0
+
+	err := conn.ListAccountAssignmentsPagesWithContext(ctx, input, func(page *ssoadmin.ListAccountAssignmentsOutput, lastPage bool) bool {
+		pages++
+
+		if page != nil {
+			for _, accountAssignment := range page.AccountAssignments {
+				if accountAssignment == nil {
+					continue
+				}
+
+				if !limiter.add() {
+					return false
+				}
+
+				results = append(results, accountAssignment)
+			}
+		}
+
+		if !lastPage && pages >= accountAssignmentsMaxPages {
+			exceeded = true
+			return false
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if exceeded {
+		return nil, false, fmt.Errorf("error listing SSO Account Assignments: exceeded %d pages", accountAssignmentsMaxPages)
+	}
+
+	return results, limiter.truncated, nil
+}
+
+// ManagedPolicies returns all managed policies attached to a permission set within a specified SSO instance.
+func ManagedPolicies(ctx context.Context, conn *ssoadmin.SSOAdmin, permissionSetArn, instanceArn string) ([]*ssoadmin.AttachedManagedPolicy, error) {
+	input := &ssoadmin.ListManagedPoliciesInPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}
+
+	var results []*ssoadmin.AttachedManagedPolicy
+
+	err := conn.ListManagedPoliciesInPermissionSetPagesWithContext(ctx, input, func(page *ssoadmin.ListManagedPoliciesInPermissionSetOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, policy := range page.AttachedManagedPolicies {
+			if policy == nil {
+				continue
+			}
+
+			results = append(results, policy)
+		}
+
+		return !lastPage
+	})
+
+	return results, err
+}
+
+// ManagedPolicy returns the managed policy attached to a permission set within a specified SSO instance,
+// or nil if it is not attached.
+func ManagedPolicy(ctx context.Context, conn *ssoadmin.SSOAdmin, managedPolicyArn, permissionSetArn, instanceArn string) (*ssoadmin.AttachedManagedPolicy, error) {
+	input := &ssoadmin.ListManagedPoliciesInPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}
+
+	var result *ssoadmin.AttachedManagedPolicy
+
+	err := conn.ListManagedPoliciesInPermissionSetPagesWithContext(ctx, input, func(page *ssoadmin.ListManagedPoliciesInPermissionSetOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, policy := range page.AttachedManagedPolicies {
+			if policy == nil {
+				continue
+			}
+
+			if aws.StringValue(policy.Arn) == managedPolicyArn {
+				result = policy
+				return false
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CustomerManagedPolicy returns the customer managed policy reference attached to a permission set
+// within a specified SSO instance, or nil if it is not attached.
+func CustomerManagedPolicy(ctx context.Context, conn *ssoadmin.SSOAdmin, name, path, permissionSetArn, instanceArn string) (*ssoadmin.CustomerManagedPolicyReference, error) {
+	input := &ssoadmin.ListCustomerManagedPolicyReferencesInPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}
+
+	var result *ssoadmin.CustomerManagedPolicyReference
+
+	err := conn.ListCustomerManagedPolicyReferencesInPermissionSetPagesWithContext(ctx, input, func(page *ssoadmin.ListCustomerManagedPolicyReferencesInPermissionSetOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, reference := range page.CustomerManagedPolicyReferences {
+			if reference == nil {
+				continue
+			}
+
+			if aws.StringValue(reference.Name) == name && aws.StringValue(reference.Path) == path {
+				result = reference
+				return false
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
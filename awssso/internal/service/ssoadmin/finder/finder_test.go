@@ -0,0 +1,116 @@
+package finder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestAccountAssignments(t *testing.T) {
+	api := mockapi.New(t, map[string][]mockapi.Response{
+		"ListAccountAssignments": {
+			{Body: map[string]interface{}{
+				"AccountAssignments": []interface{}{
+					map[string]interface{}{"PrincipalId": "11111111-1111-1111-1111-111111111111", "PrincipalType": "USER"},
+				},
+				"NextToken": "page-2",
+			}},
+			{Body: map[string]interface{}{
+				"AccountAssignments": []interface{}{
+					map[string]interface{}{"PrincipalId": "22222222-2222-2222-2222-222222222222", "PrincipalType": "GROUP"},
+				},
+			}},
+		},
+	})
+
+	accountAssignments, err := AccountAssignments(context.Background(), ssoadmin.New(api.Session()), &ssoadmin.ListAccountAssignmentsInput{
+		AccountId:        aws.String("111111111111"),
+		InstanceArn:      aws.String("arn:aws:sso:::instance/ssoins-1111111111111111"),
+		PermissionSetArn: aws.String("arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"),
+	})
+
+	if err != nil {
+		t.Fatalf("error listing account assignments: %s", err)
+	}
+
+	if got, expected := len(accountAssignments), 2; got != expected {
+		t.Fatalf("got %d account assignments, expected %d", got, expected)
+	}
+
+	if got, expected := aws.StringValue(accountAssignments[1].PrincipalId), "22222222-2222-2222-2222-222222222222"; got != expected {
+		t.Errorf("got principal ID %s, expected %s", got, expected)
+	}
+
+	requests := api.Requests("ListAccountAssignments")
+
+	if got, expected := len(requests), 2; got != expected {
+		t.Fatalf("got %d ListAccountAssignments calls, expected %d", got, expected)
+	}
+
+	if got, expected := requests[1].Body["NextToken"], "page-2"; got != expected {
+		t.Errorf("got NextToken %v, expected %s", got, expected)
+	}
+}
+
+func TestPermissionSetArnsWithLimit(t *testing.T) {
+	testCases := []struct {
+		TestName          string
+		Limit             int
+		Responses         []mockapi.Response
+		ExpectedArns      int
+		ExpectedTruncated bool
+	}{
+		{
+			TestName: "no limit",
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{"PermissionSets": []interface{}{"ps-1", "ps-2"}, "NextToken": "page-2"}},
+				{Body: map[string]interface{}{"PermissionSets": []interface{}{"ps-3"}}},
+			},
+			ExpectedArns: 3,
+		},
+		{
+			TestName: "more results",
+			Limit:    2,
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{"PermissionSets": []interface{}{"ps-1", "ps-2"}, "NextToken": "page-2"}},
+				{Body: map[string]interface{}{"PermissionSets": []interface{}{"ps-3"}}},
+			},
+			ExpectedArns:      2,
+			ExpectedTruncated: true,
+		},
+		{
+			TestName: "limit reached on last result",
+			Limit:    2,
+			Responses: []mockapi.Response{
+				{Body: map[string]interface{}{"PermissionSets": []interface{}{"ps-1", "ps-2"}, "NextToken": "page-2"}},
+				{Body: map[string]interface{}{"PermissionSets": []interface{}{}}},
+			},
+			ExpectedArns: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			api := mockapi.New(t, map[string][]mockapi.Response{
+				"ListPermissionSets": testCase.Responses,
+			})
+
+			arns, truncated, err := PermissionSetArnsWithLimit(context.Background(), ssoadmin.New(api.Session()), "arn:aws:sso:::instance/ssoins-1111111111111111", testCase.Limit)
+
+			if err != nil {
+				t.Fatalf("error listing permission sets: %s", err)
+			}
+
+			if got, expected := len(arns), testCase.ExpectedArns; got != expected {
+				t.Errorf("got %d permission set ARNs, expected %d", got, expected)
+			}
+
+			if got, expected := truncated, testCase.ExpectedTruncated; got != expected {
+				t.Errorf("got truncated %t, expected %t", got, expected)
+			}
+		})
+	}
+}
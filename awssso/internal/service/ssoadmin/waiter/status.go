@@ -0,0 +1,126 @@
+package waiter
+
+import (
+	"context"
+
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	AccountAssignmentStatusNotFound = "NotFound"
+
+	AccountAssignmentStatusUnknown = "Unknown"
+)
+
+const (
+	PermissionSetProvisioningStatusNotFound = "NotFound"
+
+	PermissionSetProvisioningStatusUnknown = "Unknown"
+)
+
+// AccountAssignmentCreationStatus fetches the status of an account assignment creation request
+func AccountAssignmentCreationStatus(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, requestID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		input := &ssoadmin.DescribeAccountAssignmentCreationStatusInput{
+			AccountAssignmentCreationRequestId: aws.String(requestID),
+			InstanceArn:                        aws.String(instanceArn),
+		}
+
+		var output *ssoadmin.DescribeAccountAssignmentCreationStatusOutput
+
+		err := retryThrottled(ctx, conn, func() error {
+			var err error
+			output, err = conn.DescribeAccountAssignmentCreationStatusWithContext(ctx, input)
+			return err
+		})
+
+		if err != nil {
+			return nil, AccountAssignmentStatusUnknown, err
+		}
+
+		if output == nil || output.AccountAssignmentCreationStatus == nil {
+			return nil, AccountAssignmentStatusNotFound, nil
+		}
+
+		return accountAssignmentOperationStatus(output.AccountAssignmentCreationStatus)
+	}
+}
+
+// AccountAssignmentDeletionStatus fetches the status of an account assignment deletion request
+func AccountAssignmentDeletionStatus(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, requestID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		input := &ssoadmin.DescribeAccountAssignmentDeletionStatusInput{
+			AccountAssignmentDeletionRequestId: aws.String(requestID),
+			InstanceArn:                        aws.String(instanceArn),
+		}
+
+		var output *ssoadmin.DescribeAccountAssignmentDeletionStatusOutput
+
+		err := retryThrottled(ctx, conn, func() error {
+			var err error
+			output, err = conn.DescribeAccountAssignmentDeletionStatusWithContext(ctx, input)
+			return err
+		})
+
+		if err != nil {
+			return nil, AccountAssignmentStatusUnknown, err
+		}
+
+		if output == nil || output.AccountAssignmentDeletionStatus == nil {
+			return nil, AccountAssignmentStatusNotFound, nil
+		}
+
+		return accountAssignmentOperationStatus(output.AccountAssignmentDeletionStatus)
+	}
+}
+
+// accountAssignmentOperationStatus returns the status of an account assignment
+// operation, surfacing the failure reason as an error when the operation failed.
+func accountAssignmentOperationStatus(status *ssoadmin.AccountAssignmentOperationStatus) (interface{}, string, error) {
+	state := aws.StringValue(status.Status)
+
+	if state == ssoadmin.StatusValuesFailed {
+		return status, state, errors.New(aws.StringValue(status.FailureReason))
+	}
+
+	return status, state, nil
+}
+
+// PermissionSetProvisioningStatus fetches the status of a permission set provisioning request
+func PermissionSetProvisioningStatus(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, requestID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		input := &ssoadmin.DescribePermissionSetProvisioningStatusInput{
+			InstanceArn:                     aws.String(instanceArn),
+			ProvisionPermissionSetRequestId: aws.String(requestID),
+		}
+
+		var output *ssoadmin.DescribePermissionSetProvisioningStatusOutput
+
+		err := retryThrottled(ctx, conn, func() error {
+			var err error
+			output, err = conn.DescribePermissionSetProvisioningStatusWithContext(ctx, input)
+			return err
+		})
+
+		if err != nil {
+			return nil, PermissionSetProvisioningStatusUnknown, err
+		}
+
+		if output == nil || output.PermissionSetProvisioningStatus == nil {
+			return nil, PermissionSetProvisioningStatusNotFound, nil
+		}
+
+		status := output.PermissionSetProvisioningStatus
+		state := aws.StringValue(status.Status)
+
+		if state == ssoadmin.StatusValuesFailed {
+			return status, state, errors.New(aws.StringValue(status.FailureReason))
+		}
+
+		return status, state, nil
+	}
+}
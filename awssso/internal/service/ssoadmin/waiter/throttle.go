@@ -0,0 +1,88 @@
+package waiter
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/tfresource"
+)
+
+// Initial delay before retrying a throttled status request. It is a variable
+// so that unit tests can shorten it.
+var throttleMinDelay = 1 * time.Second
+
+// throttleMaxDelay returns the backoff cap for throttled status requests,
+// which follows the throttle delay of the client retryer configured by the
+// provider retry settings.
+func throttleMaxDelay(conn *ssoadmin.SSOAdmin) time.Duration {
+	if retryer, ok := conn.Retryer.(client.DefaultRetryer); ok && retryer.MaxThrottleDelay > 0 {
+		return retryer.MaxThrottleDelay
+	}
+
+	return client.DefaultRetryerMaxThrottleDelay
+}
+
+// throttleDelay returns the jittered exponential backoff delay for the given
+// retry attempt, capped at maxDelay.
+func throttleDelay(attempt int, maxDelay time.Duration) time.Duration {
+	delay := maxDelay
+
+	if attempt < 32 && throttleMinDelay<<uint(attempt) < maxDelay {
+		delay = throttleMinDelay << uint(attempt)
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// RetryThrottled calls f until it returns an error that is not retryable, such
+// as throttling or a conflicting concurrent operation, backing off between
+// attempts as status requests do. Once timeout has elapsed, the last retryable
+// error is returned.
+func RetryThrottled(ctx context.Context, conn *ssoadmin.SSOAdmin, timeout time.Duration, f func() error) error {
+	return retryThrottledUntil(ctx, conn, time.Now().Add(timeout), f)
+}
+
+// retryThrottled calls f until it returns an error that is not retryable,
+// backing off between attempts. Retries are bounded by the deadline of ctx,
+// which the waiters set to their timeout, rather than a number of attempts.
+func retryThrottled(ctx context.Context, conn *ssoadmin.SSOAdmin, f func() error) error {
+	deadline, _ := ctx.Deadline()
+
+	return retryThrottledUntil(ctx, conn, deadline, f)
+}
+
+// retryThrottledUntil is retryThrottled, giving up at deadline unless it is
+// zero.
+func retryThrottledUntil(ctx context.Context, conn *ssoadmin.SSOAdmin, deadline time.Time, f func() error) error {
+	maxDelay := throttleMaxDelay(conn)
+
+	for attempt := 0; ; attempt++ {
+		err := f()
+
+		if !tfresource.Retryable(err) {
+			return err
+		}
+
+		delay := throttleDelay(attempt, maxDelay)
+
+		if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+			return err
+		}
+
+		log.Printf("[DEBUG] SSO Admin request failed with retryable error, retrying in %s: %s", delay, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
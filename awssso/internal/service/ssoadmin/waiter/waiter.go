@@ -0,0 +1,106 @@
+package waiter
+
+import (
+	"context"
+
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	// Default amount of time to wait for an account assignment to be created
+	AccountAssignmentCreatedTimeout = 5 * time.Minute
+
+	// Default amount of time to wait for an account assignment to be deleted
+	AccountAssignmentDeletedTimeout = 5 * time.Minute
+
+	// Maximum amount of time to wait for a permission set to be provisioned
+	PermissionSetProvisionedTimeout = 10 * time.Minute
+
+	// Maximum amount of time to wait for a newly created permission set to be
+	// found, as the SSO Admin API is eventually consistent
+	PermissionSetPropagationTimeout = 30 * time.Second
+
+	// Maximum amount of time to retry listing SSO instances while throttled
+	InstancesThrottledTimeout = 2 * time.Minute
+)
+
+// Polling intervals are variables so that unit tests can shorten them.
+var (
+	accountAssignmentMinTimeout = 5 * time.Second
+	permissionSetMinTimeout     = 5 * time.Second
+)
+
+// AccountAssignmentCreated waits up to timeout for an account assignment creation request to succeed
+func AccountAssignmentCreated(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, requestID string, timeout time.Duration) (*ssoadmin.AccountAssignmentOperationStatus, error) {
+	// The refresh context bounds retries of throttled status requests by the
+	// waiter timeout, and stops them once the waiter returns.
+	refreshCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{ssoadmin.StatusValuesInProgress},
+		Target:     []string{ssoadmin.StatusValuesSucceeded},
+		Refresh:    AccountAssignmentCreationStatus(refreshCtx, conn, instanceArn, requestID),
+		Timeout:    timeout,
+		MinTimeout: accountAssignmentMinTimeout,
+	}
+
+	outputRaw, err := stateConf.WaitForStateContext(ctx)
+
+	if output, ok := outputRaw.(*ssoadmin.AccountAssignmentOperationStatus); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// AccountAssignmentDeleted waits up to timeout for an account assignment deletion request to succeed
+func AccountAssignmentDeleted(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, requestID string, timeout time.Duration) (*ssoadmin.AccountAssignmentOperationStatus, error) {
+	// The refresh context bounds retries of throttled status requests by the
+	// waiter timeout, and stops them once the waiter returns.
+	refreshCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{ssoadmin.StatusValuesInProgress},
+		Target:     []string{ssoadmin.StatusValuesSucceeded},
+		Refresh:    AccountAssignmentDeletionStatus(refreshCtx, conn, instanceArn, requestID),
+		Timeout:    timeout,
+		MinTimeout: accountAssignmentMinTimeout,
+	}
+
+	outputRaw, err := stateConf.WaitForStateContext(ctx)
+
+	if output, ok := outputRaw.(*ssoadmin.AccountAssignmentOperationStatus); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// PermissionSetProvisioned waits for a permission set provisioning request to succeed
+func PermissionSetProvisioned(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, requestID string) (*ssoadmin.PermissionSetProvisioningStatus, error) {
+	// The refresh context bounds retries of throttled status requests by the
+	// waiter timeout, and stops them once the waiter returns.
+	refreshCtx, cancel := context.WithTimeout(ctx, PermissionSetProvisionedTimeout)
+	defer cancel()
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{ssoadmin.StatusValuesInProgress},
+		Target:     []string{ssoadmin.StatusValuesSucceeded},
+		Refresh:    PermissionSetProvisioningStatus(refreshCtx, conn, instanceArn, requestID),
+		Timeout:    PermissionSetProvisionedTimeout,
+		MinTimeout: permissionSetMinTimeout,
+	}
+
+	outputRaw, err := stateConf.WaitForStateContext(ctx)
+
+	if output, ok := outputRaw.(*ssoadmin.PermissionSetProvisioningStatus); ok {
+		return output, err
+	}
+
+	return nil, err
+}
@@ -0,0 +1,282 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/tfresource"
+)
+
+func init() {
+	accountAssignmentMinTimeout = 0
+	permissionSetMinTimeout = 0
+	throttleMinDelay = 0
+}
+
+func testAccountAssignmentStatus(status, failureReason string) map[string]interface{} {
+	return map[string]interface{}{
+		"AccountAssignmentCreationStatus": map[string]interface{}{
+			"FailureReason": failureReason,
+			"RequestId":     "11111111-2222-3333-4444-555555555555",
+			"Status":        status,
+		},
+	}
+}
+
+func TestAccountAssignmentCreated(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Responses     []mockapi.Response
+		ExpectedError *regexp.Regexp
+		ExpectedCalls int
+	}{
+		{
+			TestName: "in progress then succeeded",
+			Responses: []mockapi.Response{
+				{Body: testAccountAssignmentStatus(ssoadmin.StatusValuesInProgress, "")},
+				{Body: testAccountAssignmentStatus(ssoadmin.StatusValuesInProgress, "")},
+				{Body: testAccountAssignmentStatus(ssoadmin.StatusValuesSucceeded, "")},
+			},
+			ExpectedCalls: 3,
+		},
+		{
+			TestName: "in progress then failed",
+			Responses: []mockapi.Response{
+				{Body: testAccountAssignmentStatus(ssoadmin.StatusValuesInProgress, "")},
+				{Body: testAccountAssignmentStatus(ssoadmin.StatusValuesFailed, "principal does not exist")},
+			},
+			ExpectedError: regexp.MustCompile(`principal does not exist`),
+			ExpectedCalls: 2,
+		},
+		{
+			TestName: "throttled then succeeded",
+			Responses: []mockapi.Response{
+				{ErrorCode: ssoadmin.ErrCodeThrottlingException},
+				{ErrorCode: ssoadmin.ErrCodeThrottlingException},
+				{Body: testAccountAssignmentStatus(ssoadmin.StatusValuesSucceeded, "")},
+			},
+			ExpectedCalls: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			api := mockapi.New(t, map[string][]mockapi.Response{
+				"DescribeAccountAssignmentCreationStatus": testCase.Responses,
+			})
+			conn := ssoadmin.New(api.Session())
+
+			output, err := AccountAssignmentCreated(context.Background(), conn, "arn:aws:sso:::instance/ssoins-1111111111111111", "11111111-2222-3333-4444-555555555555", AccountAssignmentCreatedTimeout)
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+
+			if got, expected := len(api.Requests("DescribeAccountAssignmentCreationStatus")), testCase.ExpectedCalls; got != expected {
+				t.Errorf("got %d status calls, expected %d", got, expected)
+			}
+
+			if testCase.ExpectedError == nil && aws.StringValue(output.Status) != ssoadmin.StatusValuesSucceeded {
+				t.Errorf("got status %s, expected %s", aws.StringValue(output.Status), ssoadmin.StatusValuesSucceeded)
+			}
+		})
+	}
+}
+
+func testPermissionSetProvisioningStatus(status, failureReason string) map[string]interface{} {
+	return map[string]interface{}{
+		"PermissionSetProvisioningStatus": map[string]interface{}{
+			"FailureReason": failureReason,
+			"RequestId":     "11111111-2222-3333-4444-555555555555",
+			"Status":        status,
+		},
+	}
+}
+
+func TestPermissionSetProvisioned(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Responses     []mockapi.Response
+		ExpectedError *regexp.Regexp
+		ExpectedCalls int
+	}{
+		{
+			TestName: "succeeded",
+			Responses: []mockapi.Response{
+				{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")},
+			},
+			ExpectedCalls: 1,
+		},
+		{
+			TestName: "in progress then succeeded",
+			Responses: []mockapi.Response{
+				{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")},
+				{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")},
+			},
+			ExpectedCalls: 2,
+		},
+		{
+			TestName: "in progress then failed",
+			Responses: []mockapi.Response{
+				{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")},
+				{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesFailed, "policy is too large")},
+			},
+			ExpectedError: regexp.MustCompile(`policy is too large`),
+			ExpectedCalls: 2,
+		},
+		{
+			TestName: "throttled then succeeded",
+			Responses: []mockapi.Response{
+				{ErrorCode: ssoadmin.ErrCodeThrottlingException},
+				{ErrorCode: tfresource.ErrCodeTooManyRequestsException},
+				{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")},
+			},
+			ExpectedCalls: 3,
+		}, {
+			TestName: "conflict then succeeded",
+			Responses: []mockapi.Response{
+				{ErrorCode: ssoadmin.ErrCodeConflictException},
+				{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")},
+			},
+			ExpectedCalls: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			api := mockapi.New(t, map[string][]mockapi.Response{
+				"DescribePermissionSetProvisioningStatus": testCase.Responses,
+			})
+			conn := ssoadmin.New(api.Session())
+
+			_, err := PermissionSetProvisioned(context.Background(), conn, "arn:aws:sso:::instance/ssoins-1111111111111111", "11111111-2222-3333-4444-555555555555")
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+
+			if got, expected := len(api.Requests("DescribePermissionSetProvisioningStatus")), testCase.ExpectedCalls; got != expected {
+				t.Errorf("got %d status calls, expected %d", got, expected)
+			}
+		})
+	}
+}
+
+func TestPermissionSetProvisioned_contextCancelled(t *testing.T) {
+	api := mockapi.New(t, map[string][]mockapi.Response{
+		"DescribePermissionSetProvisioningStatus": {
+			{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")},
+		},
+	})
+	conn := ssoadmin.New(api.Session())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := PermissionSetProvisioned(ctx, conn, "arn:aws:sso:::instance/ssoins-1111111111111111", "11111111-2222-3333-4444-555555555555")
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancelled error, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected waiter to return promptly after cancellation, took %s", elapsed)
+	}
+
+	if got := len(api.Requests("DescribePermissionSetProvisioningStatus")); got == 0 {
+		t.Errorf("expected status to be polled before cancellation")
+	}
+}
+
+func TestThrottleDelay(t *testing.T) {
+	defer func(v time.Duration) { throttleMinDelay = v }(throttleMinDelay)
+	throttleMinDelay = time.Second
+
+	maxDelay := 10 * time.Second
+
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, maxDelay, maxDelay, maxDelay} {
+		got := throttleDelay(attempt, maxDelay)
+
+		if got < expected/2 || got > expected {
+			t.Errorf("attempt %d: got delay %s, expected between %s and %s", attempt, got, expected/2, expected)
+		}
+	}
+
+	if got := throttleDelay(100, maxDelay); got > maxDelay {
+		t.Errorf("got delay %s, expected at most %s", got, maxDelay)
+	}
+}
+
+func TestRetryThrottled_timeout(t *testing.T) {
+	throttled := awserr.New(ssoadmin.ErrCodeThrottlingException, "rate exceeded", nil)
+
+	var calls int
+
+	err := RetryThrottled(context.Background(), ssoadmin.New(mockapi.New(t, nil).Session()), 50*time.Millisecond, func() error {
+		calls++
+		return throttled
+	})
+
+	if !errors.Is(err, throttled) {
+		t.Fatalf("expected throttling error, got: %v", err)
+	}
+
+	if calls < 2 {
+		t.Errorf("got %d calls, expected throttled calls to be retried", calls)
+	}
+}
+
+func TestAccountAssignmentCreated_throttledTimeout(t *testing.T) {
+	defer func(v time.Duration) { throttleMinDelay = v }(throttleMinDelay)
+	throttleMinDelay = 10 * time.Millisecond
+
+	api := mockapi.New(t, map[string][]mockapi.Response{
+		"DescribeAccountAssignmentCreationStatus": {{ErrorCode: ssoadmin.ErrCodeThrottlingException}},
+	})
+	conn := ssoadmin.New(api.Session())
+
+	start := time.Now()
+	_, err := AccountAssignmentCreated(context.Background(), conn, "arn:aws:sso:::instance/ssoins-1111111111111111", "11111111-2222-3333-4444-555555555555", 100*time.Millisecond)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("waiter returned after %s, expected throttled retries to stop at its timeout", elapsed)
+	}
+
+	calls := len(api.Requests("DescribeAccountAssignmentCreationStatus"))
+
+	time.Sleep(200 * time.Millisecond)
+
+	if got := len(api.Requests("DescribeAccountAssignmentCreationStatus")); got != calls {
+		t.Errorf("got %d status calls after the waiter returned, expected retries to stop at %d", got, calls)
+	}
+}
@@ -0,0 +1,36 @@
+package tfresource
+
+import (
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+)
+
+// ErrCodeTooManyRequestsException is the generic throttling error code, which
+// the SSO Admin API returns alongside its modeled ThrottlingException.
+const ErrCodeTooManyRequestsException = "TooManyRequestsException"
+
+// RetryableErrorCodes are the SSO Admin and Identity Store error codes of
+// transient failures, which succeed when the request is retried.
+var RetryableErrorCodes = []string{
+	identitystore.ErrCodeConflictException,
+	identitystore.ErrCodeInternalServerException,
+	identitystore.ErrCodeThrottlingException,
+	ssoadmin.ErrCodeConflictException,
+	ssoadmin.ErrCodeInternalServerException,
+	ssoadmin.ErrCodeThrottlingException,
+	ErrCodeTooManyRequestsException,
+}
+
+// Retryable returns true if the error or a wrapped error is a transient SSO
+// Admin or Identity Store error, such as throttling or a conflicting
+// concurrent operation.
+func Retryable(err error) bool {
+	for _, code := range RetryableErrorCodes {
+		if tfawserr.ErrCodeEquals(err, code) {
+			return true
+		}
+	}
+
+	return false
+}
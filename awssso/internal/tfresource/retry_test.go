@@ -0,0 +1,87 @@
+package tfresource
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+)
+
+func TestRetryable(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Err      error
+		Expected bool
+	}{
+		{
+			Name: "nil error",
+		},
+		{
+			Name: "other error",
+			Err:  errors.New("test"),
+		},
+		{
+			Name:     "ssoadmin throttling",
+			Err:      awserr.New(ssoadmin.ErrCodeThrottlingException, "rate exceeded", nil),
+			Expected: true,
+		},
+		{
+			Name:     "too many requests",
+			Err:      awserr.New("TooManyRequestsException", "rate exceeded", nil),
+			Expected: true,
+		},
+		{
+			Name:     "ssoadmin conflict",
+			Err:      awserr.New(ssoadmin.ErrCodeConflictException, "permission set is being provisioned", nil),
+			Expected: true,
+		},
+		{
+			Name:     "ssoadmin internal server",
+			Err:      awserr.New(ssoadmin.ErrCodeInternalServerException, "internal failure", nil),
+			Expected: true,
+		},
+		{
+			Name:     "identitystore throttling",
+			Err:      awserr.New(identitystore.ErrCodeThrottlingException, "rate exceeded", nil),
+			Expected: true,
+		},
+		{
+			Name:     "identitystore conflict",
+			Err:      awserr.New(identitystore.ErrCodeConflictException, "conflicting operation", nil),
+			Expected: true,
+		},
+		{
+			Name:     "identitystore internal server",
+			Err:      awserr.New(identitystore.ErrCodeInternalServerException, "internal failure", nil),
+			Expected: true,
+		},
+		{
+			Name: "access denied",
+			Err:  awserr.New(ssoadmin.ErrCodeAccessDeniedException, "access denied", nil),
+		},
+		{
+			Name: "not found",
+			Err:  awserr.New(ssoadmin.ErrCodeResourceNotFoundException, "not found", nil),
+		},
+		{
+			Name: "validation",
+			Err:  awserr.New(identitystore.ErrCodeValidationException, "invalid", nil),
+		},
+		{
+			Name:     "wrapped request failure",
+			Err:      fmt.Errorf("error provisioning: %w", awserr.NewRequestFailure(awserr.New(ssoadmin.ErrCodeConflictException, "conflict", nil), 409, "11111111-2222-3333-4444-555555555555")),
+			Expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if got := Retryable(testCase.Err); got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+// testMockClient returns an AWSClient whose SSO clients are pointed at a
+// mocked API serving the given responses.
+func testMockClient(t *testing.T, responses map[string][]mockapi.Response) (*AWSClient, *mockapi.API) {
+	t.Helper()
+
+	api := mockapi.New(t, responses)
+
+	config := testConfig()
+	config.Endpoints["identitystore"] = api.URL
+	config.Endpoints["ssoadmin"] = api.URL
+
+	client := testConfigClient(t, config)
+
+	// Provision immediately rather than waiting for other changes to join.
+	client.provisioner.delay = 0
+
+	return client, api
+}
+
+// testResourceApply plans and applies the given configuration for a resource,
+// starting from state (nil for create), and returns the resulting state.
+func testResourceApply(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) *terraform.InstanceState {
+	t.Helper()
+
+	diff, err := testResourceDiff(r, state, raw, meta)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	newState, diags := r.Apply(context.Background(), state, diff, meta)
+
+	if diags.HasError() {
+		t.Fatalf("error applying resource: %v", diags)
+	}
+
+	return newState
+}
+
+// testResourceDiff plans the given configuration for a resource against state.
+func testResourceDiff(r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) (*terraform.InstanceDiff, error) {
+	return r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta)
+}
+
+// testResourceDestroy destroys the resource in the given state.
+func testResourceDestroy(t *testing.T, r *schema.Resource, state *terraform.InstanceState, meta interface{}) {
+	t.Helper()
+
+	_, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, meta)
+
+	if diags.HasError() {
+		t.Fatalf("error destroying resource: %v", diags)
+	}
+}
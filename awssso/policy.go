@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// normalizePolicyJSON returns the policy document with consistent key ordering
+// and whitespace, so that documents differing only in formatting compare equal.
+func normalizePolicyJSON(policy string) (string, error) {
+	if policy == "" {
+		return "", nil
+	}
+
+	var v interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(policy)))
+	// Preserve numbers exactly rather than converting them to float64.
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&v); err != nil {
+		return policy, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return policy, errors.New("unexpected data after policy document")
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	// Policy conditions commonly contain characters such as & and <, which must
+	// not be rewritten as escape sequences.
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(v); err != nil {
+		return policy, err
+	}
+
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// suppressEquivalentPolicyDiffs suppresses differences between semantically
+// equivalent policy documents.
+func suppressEquivalentPolicyDiffs(k, old, new string, d *schema.ResourceData) bool {
+	oldPolicy, err := normalizePolicyJSON(old)
+
+	if err != nil {
+		return false
+	}
+
+	newPolicy, err := normalizePolicyJSON(new)
+
+	if err != nil {
+		return false
+	}
+
+	return oldPolicy == newPolicy
+}
+
+// managedPolicyArnInPartition returns an AWS managed policy ARN in the given
+// partition, as AWS managed policies have the same names in every partition.
+// Other ARNs are returned unchanged.
+func managedPolicyArnInPartition(policyArn, partition string) string {
+	v, err := arn.Parse(policyArn)
+
+	if err != nil || partition == "" || v.Service != "iam" || v.AccountID != "aws" {
+		return policyArn
+	}
+
+	v.Partition = partition
+
+	return v.String()
+}
+
+// suppressEquivalentManagedPolicyArnDiffs suppresses differences between AWS
+// managed policy ARNs in different partitions, such as a commercial ARN
+// configured for a provider in GovCloud, where it is attached as the GovCloud
+// policy of the same name.
+func suppressEquivalentManagedPolicyArnDiffs(k, old, new string, d *schema.ResourceData) bool {
+	v, err := arn.Parse(old)
+
+	if err != nil {
+		return false
+	}
+
+	return managedPolicyArnInPartition(new, v.Partition) == old
+}
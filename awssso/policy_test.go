@@ -0,0 +1,190 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestNormalizePolicyJSON(t *testing.T) {
+	testCases := []struct {
+		TestName string
+		Policy   string
+		Expected string
+	}{
+		{
+			TestName: "empty",
+			Policy:   "",
+			Expected: "",
+		},
+		{
+			TestName: "reordered keys and whitespace",
+			Policy: `{
+  "Version": "2012-10-17",
+  "Statement": [{"Resource": "*", "Action": "s3:GetObject", "Effect": "Allow"}]
+}`,
+			Expected: `{"Statement":[{"Action":"s3:GetObject","Effect":"Allow","Resource":"*"}],"Version":"2012-10-17"}`,
+		},
+		{
+			TestName: "preserves special characters and numbers",
+			Policy:   `{"Condition": {"NumericLessThan": {"aws:MultiFactorAuthAge": 3600}, "StringLike": {"aws:userid": "<id>&*"}}}`,
+			Expected: `{"Condition":{"NumericLessThan":{"aws:MultiFactorAuthAge":3600},"StringLike":{"aws:userid":"<id>&*"}}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			got, err := normalizePolicyJSON(testCase.Policy)
+
+			if err != nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %s, expected %s", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestNormalizePolicyJSON_invalid(t *testing.T) {
+	for _, policy := range []string{`{"Version":`, `{"Version":"2012-10-17"} {}`} {
+		if _, err := normalizePolicyJSON(policy); err == nil {
+			t.Errorf("expected error for %s, got no error", policy)
+		}
+	}
+}
+
+func TestSuppressEquivalentPolicyDiffs(t *testing.T) {
+	testCases := []struct {
+		TestName string
+		Old      string
+		New      string
+		Expected bool
+	}{
+		{
+			TestName: "reordered keys",
+			Old:      `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"sts:AssumeRole","Resource":"*"}]}`,
+			New:      `{"Statement":[{"Resource":"*","Action":"sts:AssumeRole","Effect":"Allow"}],"Version":"2012-10-17"}`,
+			Expected: true,
+		},
+		{
+			TestName: "differing whitespace",
+			Old:      `{"Version":"2012-10-17","Statement":[]}`,
+			New:      "{\n\t\"Version\": \"2012-10-17\",\n\t\"Statement\": [ ]\n}\n",
+			Expected: true,
+		},
+		{
+			TestName: "different effect",
+			Old:      `{"Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`,
+			New:      `{"Statement":[{"Effect":"Deny","Action":"*","Resource":"*"}]}`,
+			Expected: false,
+		},
+		{
+			TestName: "invalid JSON",
+			Old:      `{"Statement":[]}`,
+			New:      `{"Statement":[`,
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			if got := suppressEquivalentPolicyDiffs("inline_policy", testCase.Old, testCase.New, nil); got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestManagedPolicyArnInPartition(t *testing.T) {
+	testCases := []struct {
+		TestName  string
+		PolicyArn string
+		Partition string
+		Expected  string
+	}{
+		{
+			TestName:  "commercial to GovCloud",
+			PolicyArn: "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			Partition: "aws-us-gov",
+			Expected:  "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess",
+		},
+		{
+			TestName:  "same partition",
+			PolicyArn: "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess",
+			Partition: "aws-us-gov",
+			Expected:  "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess",
+		},
+		{
+			TestName:  "unknown partition",
+			PolicyArn: "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			Expected:  "arn:aws:iam::aws:policy/ReadOnlyAccess",
+		},
+		{
+			TestName:  "customer managed policy",
+			PolicyArn: "arn:aws:iam::123456789012:policy/Custom",
+			Partition: "aws-us-gov",
+			Expected:  "arn:aws:iam::123456789012:policy/Custom",
+		},
+		{
+			TestName:  "invalid ARN",
+			PolicyArn: "ReadOnlyAccess",
+			Partition: "aws-us-gov",
+			Expected:  "ReadOnlyAccess",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			if got := managedPolicyArnInPartition(testCase.PolicyArn, testCase.Partition); got != testCase.Expected {
+				t.Errorf("got %s, expected %s", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentManagedPolicyArnDiffs(t *testing.T) {
+	testCases := []struct {
+		TestName string
+		Old      string
+		New      string
+		Expected bool
+	}{
+		{
+			TestName: "commercial configured in GovCloud",
+			Old:      "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess",
+			New:      "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			Expected: true,
+		},
+		{
+			TestName: "commercial configured in China",
+			Old:      "arn:aws-cn:iam::aws:policy/ReadOnlyAccess",
+			New:      "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			Expected: true,
+		},
+		{
+			TestName: "different policy",
+			Old:      "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess",
+			New:      "arn:aws:iam::aws:policy/AdministratorAccess",
+			Expected: false,
+		},
+		{
+			TestName: "customer managed policy",
+			Old:      "arn:aws-us-gov:iam::123456789012:policy/Custom",
+			New:      "arn:aws:iam::123456789012:policy/Custom",
+			Expected: false,
+		},
+		{
+			TestName: "new resource",
+			New:      "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			if got := suppressEquivalentManagedPolicyArnDiffs("managed_policy_arn", testCase.Old, testCase.New, nil); got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package aws
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"regexp"
 	"strings"
 
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
@@ -40,6 +47,8 @@ func Provider() *schema.Provider {
 
 			"assume_role": assumeRoleSchema(),
 
+			"assume_role_with_web_identity": assumeRoleWithWebIdentitySchema(),
+
 			"shared_credentials_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -47,6 +56,49 @@ func Provider() *schema.Provider {
 				Description: descriptions["shared_credentials_file"],
 			},
 
+			"shared_config_files": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: descriptions["shared_config_files"],
+			},
+
+			"shared_credentials_files": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"shared_credentials_file"},
+				Description:   descriptions["shared_credentials_files"],
+			},
+
+			"sso_admin_role_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["sso_admin_role_arn"],
+				ValidateFunc: validateArn,
+			},
+
+			"sts_regional_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["sts_regional_endpoint"],
+				ValidateFunc: validation.StringInSlice([]string{stsRegionalEndpointLegacy, stsRegionalEndpointRegional}, false),
+			},
+
+			"use_dualstack_endpoint": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["use_dualstack_endpoint"],
+			},
+
+			"use_fips_endpoint": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["use_fips_endpoint"],
+			},
+
 			"token": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -56,13 +108,12 @@ func Provider() *schema.Provider {
 
 			"region": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					"AWS_REGION",
 					"AWS_DEFAULT_REGION",
 				}, nil),
-				Description:  descriptions["region"],
-				InputDefault: "us-east-1", // lintignore:AWSAT003
+				Description: descriptions["region"],
 			},
 
 			"max_retries": {
@@ -72,6 +123,22 @@ func Provider() *schema.Provider {
 				Description: descriptions["max_retries"],
 			},
 
+			"retry_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      retryModeStandard,
+				Description:  descriptions["retry_mode"],
+				ValidateFunc: validation.StringInSlice([]string{retryModeAdaptive, retryModeStandard}, false),
+			},
+
+			"additional_regions": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: descriptions["additional_regions"],
+				Set:         schema.HashString,
+			},
+
 			"allowed_account_ids": {
 				Type:          schema.TypeSet,
 				Elem:          &schema.Schema{Type: schema.TypeString},
@@ -88,6 +155,13 @@ func Provider() *schema.Provider {
 				Set:           schema.HashString,
 			},
 
+			"default_session_duration": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["default_session_duration"],
+				ValidateFunc: validateSsoSessionDuration,
+			},
+
 			"default_tags": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -105,6 +179,20 @@ func Provider() *schema.Provider {
 				},
 			},
 
+			"ec2_metadata_service_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["ec2_metadata_service_endpoint"],
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+
+			"ec2_metadata_service_endpoint_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["ec2_metadata_service_endpoint_mode"],
+				ValidateFunc: validation.StringInSlice([]string{ec2MetadataServiceEndpointModeIPv4, ec2MetadataServiceEndpointModeIPv6}, false),
+			},
+
 			"endpoints": endpointsSchema(),
 
 			"ignore_tags": {
@@ -119,7 +207,7 @@ func Provider() *schema.Provider {
 							Optional:    true,
 							Elem:        &schema.Schema{Type: schema.TypeString},
 							Set:         schema.HashString,
-							Description: "Resource tag keys to ignore across all resources.",
+							Description: "Resource tag keys to ignore across all resources. Merged with any comma-separated keys in the " + ignoreTagKeysEnvVar + " environment variable.",
 						},
 						"key_prefixes": {
 							Type:        schema.TypeSet,
@@ -132,6 +220,27 @@ func Provider() *schema.Provider {
 				},
 			},
 
+			"custom_ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: descriptions["custom_ca_bundle"],
+			},
+
+			"http_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: descriptions["http_proxy"],
+			},
+
+			"auto_provision": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: descriptions["auto_provision"],
+			},
+
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -151,6 +260,7 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Default:     false,
 				Description: descriptions["skip_get_ec2_platforms"],
+				Deprecated:  "The provider no longer calls EC2, so this argument has no effect and will be removed in a future version.",
 			},
 
 			"skip_region_validation": {
@@ -180,23 +290,56 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: descriptions["s3_force_path_style"],
 			},
+
+			"user_agent_extra": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["user_agent_extra"],
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"awssso_role": dataSourceAwsSsoRole(),
+			"awssso_account_assignments":                dataSourceAwsSsoAccountAssignments(),
+			"awssso_account_principals":                 dataSourceAwsSsoAccountPrincipals(),
+			"awssso_caller_identity":                    dataSourceAwsSsoCallerIdentity(),
+			"awssso_group":                              dataSourceAwsSsoGroup(),
+			"awssso_group_memberships":                  dataSourceAwsSsoGroupMemberships(),
+			"awssso_groups":                             dataSourceAwsSsoGroups(),
+			"awssso_identity_store":                     dataSourceAwsSsoIdentityStore(),
+			"awssso_instance":                           dataSourceAwsSsoInstance(),
+			"awssso_managed_policies_in_permission_set": dataSourceAwsSsoManagedPoliciesInPermissionSet(),
+			"awssso_permission_set":                     dataSourceAwsSsoPermissionSet(),
+			"awssso_permission_set_inline_policy":       dataSourceAwsSsoPermissionSetInlinePolicy(),
+			"awssso_permission_sets":                    dataSourceAwsSsoPermissionSets(),
+			"awssso_provisioned_permission_sets":        dataSourceAwsSsoProvisionedPermissionSets(),
+			"awssso_role":                               dataSourceAwsSsoRole(),
+			"awssso_tags":                               dataSourceAwsSsoTags(),
+			"awssso_user":                               dataSourceAwsSsoUser(),
 		},
 
-		ResourcesMap: map[string]*schema.Resource{},
+		ResourcesMap: map[string]*schema.Resource{
+			"awssso_account_assignment":                 resourceAwsSsoAccountAssignment(),
+			"awssso_account_assignments":                resourceAwsSsoAccountAssignments(),
+			"awssso_application_assignment":             resourceAwsSsoApplicationAssignment(),
+			"awssso_customer_managed_policy_attachment": resourceAwsSsoCustomerManagedPolicyAttachment(),
+			"awssso_group":                              resourceAwsSsoGroup(),
+			"awssso_group_membership":                   resourceAwsSsoGroupMembership(),
+			"awssso_managed_policy_attachment":          resourceAwsSsoManagedPolicyAttachment(),
+			"awssso_permission_set":                     resourceAwsSsoPermissionSet(),
+			"awssso_permission_set_inline_policy":       resourceAwsSsoPermissionSetInlinePolicy(),
+			"awssso_permissions_boundary":               resourceAwsSsoPermissionsBoundary(),
+			"awssso_user":                               resourceAwsSsoUser(),
+		},
 	}
 
-	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
 			// Terraform 0.12 introduced this field to the protocol
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return providerConfigure(d, terraformVersion)
+		return providerConfigure(ctx, d, terraformVersion)
 	}
 
 	return provider
@@ -208,7 +351,8 @@ var endpointServiceNames []string
 func init() {
 	descriptions = map[string]string{
 		"region": "The region where AWS operations will take place. Examples\n" +
-			"are us-east-1, us-west-2, etc.", // lintignore:AWSAT003
+			"are us-east-1, us-west-2, etc. When not set and skip_region_validation\n" +
+			"is enabled, defaults to us-gov-west-1 or cn-north-1 for GovCloud or China role ARNs.", // lintignore:AWSAT003
 
 		"access_key": "The access key for API operations. You can retrieve this\n" +
 			"from the 'Security & Credentials' section of the AWS console.",
@@ -222,6 +366,24 @@ func init() {
 		"shared_credentials_file": "The path to the shared credentials file. If not set\n" +
 			"this defaults to ~/.aws/credentials.",
 
+		"shared_config_files": "List of paths to shared config files, such as those containing\n" +
+			"`aws sso login` profiles. If not set, this defaults to ~/.aws/config.",
+
+		"shared_credentials_files": "List of paths to shared credentials files. If not set\n" +
+			"this defaults to ~/.aws/credentials.",
+
+		"sso_admin_role_arn": "Amazon Resource Name of an IAM Role to assume for SSO Admin API calls only,\n" +
+			"such as a role in the management account for delegated administrators.",
+
+		"sts_regional_endpoint": "Resolve STS to the `regional` endpoint for the configured region,\n" +
+			"or the global `legacy` endpoint where one exists.",
+
+		"use_dualstack_endpoint": "Resolve service endpoints to their dualstack endpoints, which support IPv6.\n" +
+			"Endpoints configured in the endpoints block are used as is.",
+
+		"use_fips_endpoint": "Resolve service endpoints to their FIPS 140-2 validated endpoints.\n" +
+			"Endpoints configured in the endpoints block are used as is.",
+
 		"token": "session token. A session token is only required if you are\n" +
 			"using temporary security credentials.",
 
@@ -229,8 +391,30 @@ func init() {
 			"being executed. If the API request still fails, an error is\n" +
 			"thrown.",
 
+		"retry_mode": "Specifies how retries are attempted. Valid values are `standard` and\n" +
+			"`adaptive`, which backs off further when requests are throttled.",
+
+		"default_session_duration": "The ISO-8601 session duration, such as PT8H, used by new permission sets\n" +
+			"that do not set session_duration. Defaults to PT1H.",
+
+		"ec2_metadata_service_endpoint": "Address of the EC2 metadata service endpoint to use.",
+
+		"ec2_metadata_service_endpoint_mode": "Protocol to use with the EC2 metadata service endpoint.\n" +
+			"Valid values are `IPv4` and `IPv6`.",
+
 		"endpoint": "Use this to override the default service endpoint URL",
 
+		"custom_ca_bundle": "The path to a file containing PEM encoded certificates to trust\n" +
+			"instead of the system roots when accessing the AWS API.",
+
+		"http_proxy": "The address of an HTTP proxy to use when accessing the AWS API.\n" +
+			"If not set, the HTTP_PROXY and HTTPS_PROXY environment variables are used.",
+
+		"auto_provision": "Provision permission sets to the accounts they are provisioned to after\n" +
+			"changes to their policies or permissions boundary. Each provision waits two seconds\n" +
+			"before starting, so that other changes to the same permission set in the apply share it.\n" +
+			"Default value is `true`.",
+
 		"insecure": "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted," +
 			"default value is `false`",
 
@@ -240,6 +424,9 @@ func init() {
 		"skip_get_ec2_platforms": "Skip getting the supported EC2 platforms. " +
 			"Used by users that don't have ec2:DescribeAccountAttributes permissions.",
 
+		"additional_regions": "Regions to accept in addition to those known to the provider,\n" +
+			"such as newly launched regions.",
+
 		"skip_region_validation": "Skip static validation of region name. " +
 			"Used by users of alternative AWS-like APIs or users w/ access to regions that are not public (yet).",
 
@@ -253,6 +440,9 @@ func init() {
 			"i.e., http://s3.amazonaws.com/BUCKET/KEY. By default, the S3 client will\n" +
 			"use virtual hosted bucket addressing when possible\n" +
 			"(http://BUCKET.s3.amazonaws.com/KEY). Specific to the Amazon S3 service.",
+
+		"user_agent_extra": "Product information appended to the User-Agent of all API requests,\n" +
+			"such as `my-pipeline/1.2.0`.",
 	}
 
 	endpointServiceNames = []string{
@@ -413,90 +603,67 @@ func init() {
 	}
 }
 
-func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	config := Config{
-		AccessKey:               d.Get("access_key").(string),
-		SecretKey:               d.Get("secret_key").(string),
-		Profile:                 d.Get("profile").(string),
-		Token:                   d.Get("token").(string),
-		Region:                  d.Get("region").(string),
-		CredsFilename:           d.Get("shared_credentials_file").(string),
-		DefaultTagsConfig:       expandProviderDefaultTags(d.Get("default_tags").([]interface{})),
-		Endpoints:               make(map[string]string),
-		MaxRetries:              d.Get("max_retries").(int),
-		IgnoreTagsConfig:        expandProviderIgnoreTags(d.Get("ignore_tags").([]interface{})),
-		Insecure:                d.Get("insecure").(bool),
-		SkipCredsValidation:     d.Get("skip_credentials_validation").(bool),
-		SkipGetEC2Platforms:     d.Get("skip_get_ec2_platforms").(bool),
-		SkipRegionValidation:    d.Get("skip_region_validation").(bool),
-		SkipRequestingAccountId: d.Get("skip_requesting_account_id").(bool),
-		SkipMetadataApiCheck:    d.Get("skip_metadata_api_check").(bool),
-		S3ForcePathStyle:        d.Get("s3_force_path_style").(bool),
-		terraformVersion:        terraformVersion,
-	}
-
-	if l, ok := d.Get("assume_role").([]interface{}); ok && len(l) > 0 && l[0] != nil {
-		m := l[0].(map[string]interface{})
-
-		if v, ok := m["duration_seconds"].(int); ok && v != 0 {
-			config.AssumeRoleDurationSeconds = v
-		}
-
-		if v, ok := m["external_id"].(string); ok && v != "" {
-			config.AssumeRoleExternalID = v
-		}
-
-		if v, ok := m["policy"].(string); ok && v != "" {
-			config.AssumeRolePolicy = v
-		}
-
-		if policyARNSet, ok := m["policy_arns"].(*schema.Set); ok && policyARNSet.Len() > 0 {
-			for _, policyARNRaw := range policyARNSet.List() {
-				policyARN, ok := policyARNRaw.(string)
+		AccessKey:                      d.Get("access_key").(string),
+		SecretKey:                      d.Get("secret_key").(string),
+		Profile:                        d.Get("profile").(string),
+		Token:                          d.Get("token").(string),
+		Region:                         d.Get("region").(string),
+		CredsFilename:                  d.Get("shared_credentials_file").(string),
+		DefaultSessionDuration:         d.Get("default_session_duration").(string),
+		DefaultTagsConfig:              expandProviderDefaultTags(d.Get("default_tags").([]interface{})),
+		EC2MetadataServiceEndpoint:     d.Get("ec2_metadata_service_endpoint").(string),
+		EC2MetadataServiceEndpointMode: d.Get("ec2_metadata_service_endpoint_mode").(string),
+		Endpoints:                      make(map[string]string),
+		MaxRetries:                     d.Get("max_retries").(int),
+		RetryMode:                      d.Get("retry_mode").(string),
+		IgnoreTagsConfig:               mergeEnvIgnoreTagKeys(expandProviderIgnoreTags(d.Get("ignore_tags").([]interface{}))),
+		CustomCABundle:                 d.Get("custom_ca_bundle").(string),
+		HTTPProxy:                      d.Get("http_proxy").(string),
+		Insecure:                       d.Get("insecure").(bool),
+		SkipAutoProvision:              !d.Get("auto_provision").(bool),
+		SSOAdminRoleARN:                d.Get("sso_admin_role_arn").(string),
+		STSRegionalEndpoint:            d.Get("sts_regional_endpoint").(string),
+		UseDualStackEndpoint:           d.Get("use_dualstack_endpoint").(bool),
+		UseFIPSEndpoint:                d.Get("use_fips_endpoint").(bool),
+		SkipCredsValidation:            d.Get("skip_credentials_validation").(bool),
+		SkipRegionValidation:           d.Get("skip_region_validation").(bool),
+		SkipRequestingAccountId:        d.Get("skip_requesting_account_id").(bool),
+		SkipMetadataApiCheck:           d.Get("skip_metadata_api_check").(bool),
+		S3ForcePathStyle:               d.Get("s3_force_path_style").(bool),
+		UserAgentExtra:                 d.Get("user_agent_extra").(string),
+		terraformVersion:               terraformVersion,
+	}
 
-				if !ok {
-					continue
-				}
+	for _, v := range d.Get("assume_role").([]interface{}) {
+		m, ok := v.(map[string]interface{})
 
-				config.AssumeRolePolicyARNs = append(config.AssumeRolePolicyARNs, policyARN)
-			}
+		if !ok {
+			continue
 		}
 
-		if v, ok := m["role_arn"].(string); ok && v != "" {
-			config.AssumeRoleARN = v
-		}
+		assumeRole, err := expandProviderAssumeRole(m)
 
-		if v, ok := m["session_name"].(string); ok && v != "" {
-			config.AssumeRoleSessionName = v
+		if err != nil {
+			return nil, diag.FromErr(err)
 		}
 
-		if tagMapRaw, ok := m["tags"].(map[string]interface{}); ok && len(tagMapRaw) > 0 {
-			config.AssumeRoleTags = make(map[string]string)
-
-			for k, vRaw := range tagMapRaw {
-				v, ok := vRaw.(string)
-
-				if !ok {
-					continue
-				}
-
-				config.AssumeRoleTags[k] = v
-			}
+		if assumeRole.RoleARN == "" {
+			continue
 		}
 
-		if transitiveTagKeySet, ok := m["transitive_tag_keys"].(*schema.Set); ok && transitiveTagKeySet.Len() > 0 {
-			for _, transitiveTagKeyRaw := range transitiveTagKeySet.List() {
-				transitiveTagKey, ok := transitiveTagKeyRaw.(string)
+		log.Printf("[INFO] assume_role configuration set: (ARN: %q, SessionID: %q, ExternalID: %q)", assumeRole.RoleARN, assumeRole.SessionName, assumeRole.ExternalID)
 
-				if !ok {
-					continue
-				}
+		config.AssumeRoleChain = append(config.AssumeRoleChain, assumeRole)
+	}
 
-				config.AssumeRoleTransitiveTagKeys = append(config.AssumeRoleTransitiveTagKeys, transitiveTagKey)
-			}
-		}
+	for _, v := range d.Get("shared_config_files").([]interface{}) {
+		config.SharedConfigFiles = append(config.SharedConfigFiles, v.(string))
+	}
 
-		log.Printf("[INFO] assume_role configuration set: (ARN: %q, SessionID: %q, ExternalID: %q)", config.AssumeRoleARN, config.AssumeRoleSessionName, config.AssumeRoleExternalID)
+	for _, v := range d.Get("shared_credentials_files").([]interface{}) {
+		config.SharedCredentialsFiles = append(config.SharedCredentialsFiles, v.(string))
 	}
 
 	endpointsSet := d.Get("endpoints").(*schema.Set)
@@ -508,32 +675,73 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		}
 	}
 
+	if v, ok := d.GetOk("additional_regions"); ok {
+		for _, regionRaw := range v.(*schema.Set).List() {
+			config.AdditionalRegions = append(config.AdditionalRegions, regionRaw.(string))
+		}
+	}
+
 	if v, ok := d.GetOk("allowed_account_ids"); ok {
 		for _, accountIDRaw := range v.(*schema.Set).List() {
 			config.AllowedAccountIds = append(config.AllowedAccountIds, accountIDRaw.(string))
 		}
 	}
 
+	if l, ok := d.Get("assume_role_with_web_identity").([]interface{}); ok && len(l) > 0 && l[0] != nil {
+		m := l[0].(map[string]interface{})
+
+		config.AssumeRoleWithWebIdentity = &AssumeRoleWithWebIdentity{
+			RoleARN:              m["role_arn"].(string),
+			SessionName:          m["session_name"].(string),
+			WebIdentityToken:     m["web_identity_token"].(string),
+			WebIdentityTokenFile: m["web_identity_token_file"].(string),
+		}
+	}
+
 	if v, ok := d.GetOk("forbidden_account_ids"); ok {
 		for _, accountIDRaw := range v.(*schema.Set).List() {
 			config.ForbiddenAccountIds = append(config.ForbiddenAccountIds, accountIDRaw.(string))
 		}
 	}
 
-	return config.Client()
+	// Each configuration problem is reported as its own diagnostic.
+	if err := config.Validate(); err != nil {
+		var errs *multierror.Error
+
+		if errors.As(err, &errs) {
+			var diags diag.Diagnostics
+
+			for _, err := range errs.Errors {
+				diags = append(diags, diag.FromErr(err)...)
+			}
+
+			return nil, diags
+		}
+
+		return nil, diag.FromErr(err)
+	}
+
+	client, err := config.Client(ctx)
+
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return client, nil
 }
 
 func assumeRoleSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:     schema.TypeList,
-		Optional: true,
-		MaxItems: 1,
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Roles to assume in order, each using the credentials of the previous role.",
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"duration_seconds": {
-					Type:        schema.TypeInt,
-					Optional:    true,
-					Description: "Seconds to restrict the assume role session duration.",
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Description:  "Seconds to restrict the assume role session duration, between 900 and 43200. Durations over 3600 must also be within the maximum session duration of the role.",
+					ValidateFunc: validation.IntBetween(assumeRoleMinDurationSeconds, assumeRoleMaxDurationSeconds),
 				},
 				"external_id": {
 					Type:        schema.TypeString,
@@ -541,10 +749,11 @@ func assumeRoleSchema() *schema.Schema {
 					Description: "Unique identifier that might be required for assuming a role in another account.",
 				},
 				"policy": {
-					Type:         schema.TypeString,
-					Optional:     true,
-					Description:  "IAM Policy JSON describing further restricting permissions for the IAM Role being assumed.",
-					ValidateFunc: validation.StringIsJSON,
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "IAM Policy JSON describing further restricting permissions for the IAM Role being assumed.",
+					ValidateFunc:     validation.StringIsJSON,
+					DiffSuppressFunc: suppressEquivalentPolicyDiffs,
 				},
 				"policy_arns": {
 					Type:        schema.TypeSet,
@@ -552,7 +761,7 @@ func assumeRoleSchema() *schema.Schema {
 					Description: "Amazon Resource Names (ARNs) of IAM Policies describing further restricting permissions for the IAM Role being assumed.",
 					Elem: &schema.Schema{
 						Type:         schema.TypeString,
-						ValidateFunc: validateArn,
+						ValidateFunc: validateIamPolicyArn,
 					},
 				},
 				"role_arn": {
@@ -564,7 +773,16 @@ func assumeRoleSchema() *schema.Schema {
 				"session_name": {
 					Type:        schema.TypeString,
 					Optional:    true,
-					Description: "Identifier for the assumed role session.",
+					Description: "Identifier for the assumed role session. ${partition} and ${region} are replaced with their provider values.",
+				},
+				"source_identity": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Source identity specified by the principal assuming the role.",
+					ValidateFunc: validation.All(
+						validation.StringLenBetween(2, 64),
+						validation.StringMatch(regexp.MustCompile(`^[\w+=,.@-]*$`), "must match [\\w+=,.@-]"),
+					),
 				},
 				"tags": {
 					Type:        schema.TypeMap,
@@ -583,6 +801,49 @@ func assumeRoleSchema() *schema.Schema {
 	}
 }
 
+func assumeRoleWithWebIdentitySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"assume_role"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role_arn": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Amazon Resource Name of an IAM Role to assume with a web identity token.",
+					ValidateFunc: validateArn,
+				},
+				"session_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Identifier for the assumed role session.",
+				},
+				"web_identity_token": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "OAuth 2.0 access token or OpenID Connect ID token issued by the identity provider.",
+					ExactlyOneOf: []string{
+						"assume_role_with_web_identity.0.web_identity_token",
+						"assume_role_with_web_identity.0.web_identity_token_file",
+					},
+				},
+				"web_identity_token_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Path to a file containing an OAuth 2.0 access token or OpenID Connect ID token.",
+					ExactlyOneOf: []string{
+						"assume_role_with_web_identity.0.web_identity_token",
+						"assume_role_with_web_identity.0.web_identity_token_file",
+					},
+				},
+			},
+		},
+	}
+}
+
 func endpointsSchema() *schema.Schema {
 	endpointsAttributes := make(map[string]*schema.Schema)
 
@@ -604,6 +865,80 @@ func endpointsSchema() *schema.Schema {
 	}
 }
 
+func expandProviderAssumeRole(m map[string]interface{}) (AssumeRoleConfig, error) {
+	var assumeRole AssumeRoleConfig
+
+	if v, ok := m["duration_seconds"].(int); ok && v != 0 {
+		assumeRole.DurationSeconds = v
+	}
+
+	if v, ok := m["external_id"].(string); ok && v != "" {
+		assumeRole.ExternalID = v
+	}
+
+	if v, ok := m["policy"].(string); ok && v != "" {
+		policy, err := normalizePolicyJSON(v)
+
+		if err != nil {
+			return assumeRole, fmt.Errorf("error normalizing assume_role policy: %w", err)
+		}
+
+		assumeRole.Policy = policy
+	}
+
+	if policyARNSet, ok := m["policy_arns"].(*schema.Set); ok && policyARNSet.Len() > 0 {
+		for _, policyARNRaw := range policyARNSet.List() {
+			policyARN, ok := policyARNRaw.(string)
+
+			if !ok {
+				continue
+			}
+
+			assumeRole.PolicyARNs = append(assumeRole.PolicyARNs, policyARN)
+		}
+	}
+
+	if v, ok := m["role_arn"].(string); ok && v != "" {
+		assumeRole.RoleARN = v
+	}
+
+	if v, ok := m["session_name"].(string); ok && v != "" {
+		assumeRole.SessionName = v
+	}
+
+	if v, ok := m["source_identity"].(string); ok && v != "" {
+		assumeRole.SourceIdentity = v
+	}
+
+	if tagMapRaw, ok := m["tags"].(map[string]interface{}); ok && len(tagMapRaw) > 0 {
+		assumeRole.Tags = make(map[string]string)
+
+		for k, vRaw := range tagMapRaw {
+			v, ok := vRaw.(string)
+
+			if !ok {
+				continue
+			}
+
+			assumeRole.Tags[k] = v
+		}
+	}
+
+	if transitiveTagKeySet, ok := m["transitive_tag_keys"].(*schema.Set); ok && transitiveTagKeySet.Len() > 0 {
+		for _, transitiveTagKeyRaw := range transitiveTagKeySet.List() {
+			transitiveTagKey, ok := transitiveTagKeyRaw.(string)
+
+			if !ok {
+				continue
+			}
+
+			assumeRole.TransitiveTagKeys = append(assumeRole.TransitiveTagKeys, transitiveTagKey)
+		}
+	}
+
+	return assumeRole, nil
+}
+
 func expandProviderDefaultTags(l []interface{}) *keyvaluetags.DefaultConfig {
 	if len(l) == 0 || l[0] == nil {
 		return nil
@@ -637,6 +972,34 @@ func expandProviderIgnoreTags(l []interface{}) *keyvaluetags.IgnoreConfig {
 	return ignoreConfig
 }
 
+// ignoreTagKeysEnvVar names the environment variable holding comma-separated
+// tag keys to ignore in addition to those in the provider configuration.
+const ignoreTagKeysEnvVar = "AWSSSO_IGNORE_TAG_KEYS"
+
+// mergeEnvIgnoreTagKeys adds the tag keys listed in the AWSSSO_IGNORE_TAG_KEYS
+// environment variable to the given ignore configuration, which may be nil.
+func mergeEnvIgnoreTagKeys(ignoreConfig *keyvaluetags.IgnoreConfig) *keyvaluetags.IgnoreConfig {
+	var keys []string
+
+	for _, key := range strings.Split(os.Getenv(ignoreTagKeysEnvVar), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return ignoreConfig
+	}
+
+	if ignoreConfig == nil {
+		ignoreConfig = &keyvaluetags.IgnoreConfig{}
+	}
+
+	ignoreConfig.Keys = ignoreConfig.Keys.Merge(keyvaluetags.New(keys))
+
+	return ignoreConfig
+}
+
 // ReverseDns switches a DNS hostname to reverse DNS and vice-versa.
 func ReverseDns(hostname string) string {
 	parts := strings.Split(hostname, ".")
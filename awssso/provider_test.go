@@ -0,0 +1,324 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvider_assumeRolePolicyArns(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		PolicyArn     string
+		ExpectedError bool
+	}{
+		{
+			TestName:  "valid",
+			PolicyArn: "arn:aws:iam::aws:policy/ReadOnlyAccess",
+		},
+		{
+			TestName:      "invalid",
+			PolicyArn:     "not-an-arn",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			diags := Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"region": "us-east-1",
+				"assume_role": []interface{}{
+					map[string]interface{}{
+						"role_arn":    "arn:aws:iam::123456789012:role/Admin",
+						"policy_arns": []interface{}{testCase.PolicyArn},
+					},
+				},
+			}))
+
+			if got := diags.HasError(); got != testCase.ExpectedError {
+				t.Errorf("got error %t, expected %t: %v", got, testCase.ExpectedError, diags)
+			}
+		})
+	}
+}
+
+func TestProvider_assumeRoleSourceIdentity(t *testing.T) {
+	testCases := []struct {
+		TestName       string
+		SourceIdentity string
+		ExpectedError  bool
+	}{
+		{
+			TestName:       "valid",
+			SourceIdentity: "jane@example.com",
+		},
+		{
+			TestName:       "too short",
+			SourceIdentity: "j",
+			ExpectedError:  true,
+		},
+		{
+			TestName:       "invalid characters",
+			SourceIdentity: "jane doe",
+			ExpectedError:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			diags := Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"region": "us-east-1",
+				"assume_role": []interface{}{
+					map[string]interface{}{
+						"role_arn":        "arn:aws:iam::123456789012:role/Admin",
+						"source_identity": testCase.SourceIdentity,
+					},
+				},
+			}))
+
+			if got := diags.HasError(); got != testCase.ExpectedError {
+				t.Errorf("got error %t, expected %t: %v", got, testCase.ExpectedError, diags)
+			}
+		})
+	}
+}
+
+func TestProvider_skipGetEC2PlatformsDeprecated(t *testing.T) {
+	diags := Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region":                 "us-east-1",
+		"skip_get_ec2_platforms": true,
+	}))
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got, expected := len(diags), 1; got != expected {
+		t.Fatalf("got %d diagnostics, expected %d deprecation warning: %v", got, expected, diags)
+	}
+
+	if got, expected := diags[0].Severity, diag.Warning; got != expected {
+		t.Errorf("got severity %v, expected %v", got, expected)
+	}
+}
+
+func TestProvider_assumeRoleDurationSeconds(t *testing.T) {
+	testCases := []struct {
+		TestName        string
+		DurationSeconds int
+		ExpectedError   *regexp.Regexp
+	}{
+		{
+			TestName:        "within an hour",
+			DurationSeconds: 3600,
+		},
+		{
+			TestName:        "over an hour",
+			DurationSeconds: 7200,
+		},
+		{
+			TestName:        "too short",
+			DurationSeconds: 300,
+			ExpectedError:   regexp.MustCompile(`expected assume_role.0.duration_seconds to be in the range \(900 - 43200\), got 300`),
+		},
+		{
+			TestName:        "too long",
+			DurationSeconds: 50000,
+			ExpectedError:   regexp.MustCompile(`expected assume_role.0.duration_seconds to be in the range \(900 - 43200\), got 50000`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			diags := Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"region": "us-east-1",
+				"assume_role": []interface{}{
+					map[string]interface{}{
+						"duration_seconds": testCase.DurationSeconds,
+						"role_arn":         "arn:aws:iam::123456789012:role/Admin",
+					},
+				},
+			}))
+
+			if testCase.ExpectedError == nil {
+				if diags.HasError() {
+					t.Fatalf("got unexpected error: %v", diags)
+				}
+
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if !testCase.ExpectedError.MatchString(diags[0].Summary) {
+				t.Errorf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+			}
+		})
+	}
+}
+
+func TestProvider_ignoreTagKeysEnv(t *testing.T) {
+	testCases := []struct {
+		TestName     string
+		Env          string
+		IgnoreTags   []interface{}
+		ExpectedKeys []string
+	}{
+		{
+			TestName: "env only",
+			Env:      "CostCenter, Owner",
+			ExpectedKeys: []string{
+				"CostCenter",
+				"Owner",
+			},
+		},
+		{
+			TestName: "merged with provider config",
+			Env:      "CostCenter,Owner,",
+			IgnoreTags: []interface{}{
+				map[string]interface{}{
+					"keys": []interface{}{"Owner", "Team"},
+				},
+			},
+			ExpectedKeys: []string{
+				"CostCenter",
+				"Owner",
+				"Team",
+			},
+		},
+		{
+			TestName: "provider config only",
+			IgnoreTags: []interface{}{
+				map[string]interface{}{
+					"keys": []interface{}{"Team"},
+				},
+			},
+			ExpectedKeys: []string{
+				"Team",
+			},
+		},
+		{
+			TestName: "neither",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			testSetenv(t, map[string]string{ignoreTagKeysEnvVar: testCase.Env})
+
+			raw := map[string]interface{}{}
+
+			if testCase.IgnoreTags != nil {
+				raw["ignore_tags"] = testCase.IgnoreTags
+			}
+
+			d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+			ignoreConfig := mergeEnvIgnoreTagKeys(expandProviderIgnoreTags(d.Get("ignore_tags").([]interface{})))
+
+			if testCase.ExpectedKeys == nil {
+				if ignoreConfig != nil {
+					t.Fatalf("expected no ignore configuration, got: %#v", ignoreConfig)
+				}
+
+				return
+			}
+
+			if ignoreConfig == nil {
+				t.Fatal("expected ignore configuration, got none")
+			}
+
+			got := ignoreConfig.Keys.Keys()
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, testCase.ExpectedKeys) {
+				t.Errorf("got keys %v, expected %v", got, testCase.ExpectedKeys)
+			}
+		})
+	}
+}
+
+func TestProvider_configureValidationDiagnostics(t *testing.T) {
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"access_key":            "test",
+		"allowed_account_ids":   []interface{}{"123456789012"},
+		"forbidden_account_ids": []interface{}{"210987654321"},
+		"region":                "us-east-1",
+	}))
+
+	expected := []*regexp.Regexp{
+		regexp.MustCompile(`access_key requires secret_key`),
+		regexp.MustCompile(`allowed_account_ids and forbidden_account_ids cannot both be configured`),
+	}
+
+	if got := len(diags); got != len(expected) {
+		t.Fatalf("got %d diagnostics, expected %d: %v", got, len(expected), diags)
+	}
+
+	for i, expected := range expected {
+		if diags[i].Severity != diag.Error || !expected.MatchString(diags[i].Summary) {
+			t.Errorf("diagnostic %d: expected error %s, got: %s", i, expected.String(), diags[i].Summary)
+		}
+	}
+}
+
+func TestProvider_endpoints(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Endpoints     map[string]interface{}
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName:  "known service",
+			Endpoints: map[string]interface{}{"ssoadmin": "https://ssoadmin.example.com"},
+		},
+		{
+			TestName:      "mixed case service",
+			Endpoints:     map[string]interface{}{"ssoAdmin": "https://ssoadmin.example.com"},
+			ExpectedError: regexp.MustCompile(`Invalid or unknown key`),
+		},
+		{
+			TestName:      "unknown service",
+			Endpoints:     map[string]interface{}{"ssoadmn": "https://ssoadmin.example.com"},
+			ExpectedError: regexp.MustCompile(`Invalid or unknown key`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			diags := Provider().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"region":    "us-east-1",
+				"endpoints": []interface{}{testCase.Endpoints},
+			}))
+
+			if testCase.ExpectedError == nil {
+				if diags.HasError() {
+					t.Fatalf("got unexpected error: %v", diags)
+				}
+
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if !testCase.ExpectedError.MatchString(diags[0].Summary) {
+				t.Errorf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/waiter"
+)
+
+func resourceAwsSsoAccountAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoAccountAssignmentCreate,
+		ReadContext:   resourceAwsSsoAccountAssignmentRead,
+		DeleteContext: resourceAwsSsoAccountAssignmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAwsSsoAccountAssignmentImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waiter.AccountAssignmentCreatedTimeout),
+			Delete: schema.DefaultTimeout(waiter.AccountAssignmentDeletedTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"principal_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSsoPrincipalId,
+			},
+			"principal_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    upperCaseStateFunc,
+				ValidateFunc: validation.StringInSlice(ssoadmin.PrincipalType_Values(), true),
+			},
+			"target_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAwsAccountId,
+			},
+			"target_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      ssoadmin.TargetTypeAwsAccount,
+				StateFunc:    upperCaseStateFunc,
+				ValidateFunc: validation.StringInSlice(ssoadmin.TargetType_Values(), true),
+			},
+		},
+	}
+}
+
+func resourceAwsSsoAccountAssignmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn, err := ssoInstanceArn(ctx, d, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissionSetArn := d.Get("permission_set_arn").(string)
+	principalID := d.Get("principal_id").(string)
+	principalType := upperCaseStateFunc(d.Get("principal_type"))
+	targetID := d.Get("target_id").(string)
+	targetType := upperCaseStateFunc(d.Get("target_type"))
+
+	if err := meta.(*AWSClient).ValidateTargetAccountID(targetID); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating SSO Account Assignment for %s (%s): %w", principalType, principalID, err))
+	}
+
+	id := fmt.Sprintf("%s,%s,%s,%s,%s,%s", principalID, principalType, targetID, targetType, permissionSetArn, instanceArn)
+
+	// Check for an existing assignment first, as the create request status
+	// otherwise only reports an unclear failure for duplicates.
+	accountAssignments, err := finder.AccountAssignments(ctx, conn, &ssoadmin.ListAccountAssignmentsInput{
+		AccountId:        aws.String(targetID),
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing SSO Account Assignments for %s (%s): %w", principalType, principalID, cleanAwsRequestError(err)))
+	}
+
+	for _, v := range accountAssignments {
+		if aws.StringValue(v.PrincipalType) == principalType && aws.StringValue(v.PrincipalId) == principalID {
+			return diag.Errorf("SSO Account Assignment for %s (%s) already exists, import it with ID %s", principalType, principalID, id)
+		}
+	}
+
+	input := &ssoadmin.CreateAccountAssignmentInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+		PrincipalId:      aws.String(principalID),
+		PrincipalType:    aws.String(principalType),
+		TargetId:         aws.String(targetID),
+		TargetType:       aws.String(targetType),
+	}
+
+	if err := createSsoAccountAssignment(ctx, conn, input, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+
+	return resourceAwsSsoAccountAssignmentRead(ctx, d, meta)
+}
+
+func resourceAwsSsoAccountAssignmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	idParts, err := parseSsoAccountAssignmentID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	principalID := idParts[0]
+	principalType := idParts[1]
+	targetID := idParts[2]
+	targetType := idParts[3]
+	permissionSetArn := idParts[4]
+	instanceArn := idParts[5]
+
+	accountAssignment, err := findSsoAccountAssignment(ctx, conn, principalID, principalType, targetID, permissionSetArn, instanceArn)
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] SSO Account Assignment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading SSO Account Assignment (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	if accountAssignment == nil {
+		if d.IsNewResource() {
+			return diag.Errorf("error reading SSO Account Assignment (%s): not found", d.Id())
+		}
+
+		log.Printf("[WARN] SSO Account Assignment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("instance_arn", instanceArn)
+	d.Set("permission_set_arn", accountAssignment.PermissionSetArn)
+	d.Set("principal_id", accountAssignment.PrincipalId)
+	d.Set("principal_type", accountAssignment.PrincipalType)
+	d.Set("target_id", accountAssignment.AccountId)
+	d.Set("target_type", targetType)
+
+	return nil
+}
+
+func resourceAwsSsoAccountAssignmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	idParts, err := parseSsoAccountAssignmentID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	principalID := idParts[0]
+	principalType := idParts[1]
+	targetID := idParts[2]
+	targetType := idParts[3]
+	permissionSetArn := idParts[4]
+	instanceArn := idParts[5]
+
+	// An assignment removed out-of-band is already deleted, and deleting it
+	// again would only wait for a deletion request that fails.
+	accountAssignment, err := findSsoAccountAssignment(ctx, conn, principalID, principalType, targetID, permissionSetArn, instanceArn)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading SSO Account Assignment (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	if accountAssignment == nil {
+		log.Printf("[WARN] SSO Account Assignment (%s) already deleted", d.Id())
+		return nil
+	}
+
+	input := &ssoadmin.DeleteAccountAssignmentInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+		PrincipalId:      aws.String(principalID),
+		PrincipalType:    aws.String(principalType),
+		TargetId:         aws.String(targetID),
+		TargetType:       aws.String(targetType),
+	}
+
+	if err := deleteSsoAccountAssignment(ctx, conn, input, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// createSsoAccountAssignment creates an account assignment and waits up to
+// timeout for the creation request to succeed.
+func createSsoAccountAssignment(ctx context.Context, conn *ssoadmin.SSOAdmin, input *ssoadmin.CreateAccountAssignmentInput, timeout time.Duration) error {
+	principalID := aws.StringValue(input.PrincipalId)
+	principalType := aws.StringValue(input.PrincipalType)
+
+	output, err := conn.CreateAccountAssignmentWithContext(ctx, input)
+
+	if err != nil {
+		return fmt.Errorf("error creating SSO Account Assignment for %s (%s): %w", principalType, principalID, cleanAwsRequestError(err))
+	}
+
+	if output == nil || output.AccountAssignmentCreationStatus == nil {
+		return fmt.Errorf("error creating SSO Account Assignment for %s (%s): empty output", principalType, principalID)
+	}
+
+	status := output.AccountAssignmentCreationStatus
+
+	if _, err := waiter.AccountAssignmentCreated(ctx, conn, aws.StringValue(input.InstanceArn), aws.StringValue(status.RequestId), timeout); err != nil {
+		return fmt.Errorf("error waiting for SSO Account Assignment for %s (%s) to be created: %w", principalType, principalID, cleanAwsRequestError(err))
+	}
+
+	return nil
+}
+
+// deleteSsoAccountAssignment deletes an account assignment, identified by id in
+// errors, and waits up to timeout for the deletion request to succeed. An
+// assignment that no longer exists is treated as deleted.
+func deleteSsoAccountAssignment(ctx context.Context, conn *ssoadmin.SSOAdmin, input *ssoadmin.DeleteAccountAssignmentInput, id string, timeout time.Duration) error {
+	output, err := conn.DeleteAccountAssignmentWithContext(ctx, input)
+
+	if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("error deleting SSO Account Assignment (%s): %w", id, cleanAwsRequestError(err))
+	}
+
+	if output == nil || output.AccountAssignmentDeletionStatus == nil {
+		return fmt.Errorf("error deleting SSO Account Assignment (%s): empty output", id)
+	}
+
+	status := output.AccountAssignmentDeletionStatus
+
+	if _, err := waiter.AccountAssignmentDeleted(ctx, conn, aws.StringValue(input.InstanceArn), aws.StringValue(status.RequestId), timeout); err != nil {
+		if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+			return nil
+		}
+
+		// A deletion request for an assignment removed out-of-band is accepted
+		// but then fails, so only report the failure if the assignment remains.
+		accountAssignment, findErr := findSsoAccountAssignment(ctx, conn, aws.StringValue(input.PrincipalId), aws.StringValue(input.PrincipalType), aws.StringValue(input.TargetId), aws.StringValue(input.PermissionSetArn), aws.StringValue(input.InstanceArn))
+
+		if findErr == nil && accountAssignment == nil {
+			log.Printf("[WARN] SSO Account Assignment (%s) already deleted", id)
+			return nil
+		}
+
+		return fmt.Errorf("error waiting for SSO Account Assignment (%s) to be deleted: %w", id, cleanAwsRequestError(err))
+	}
+
+	return nil
+}
+
+// findSsoAccountAssignment returns the account assignment of the principal to
+// the target account, or nil if there is none.
+func findSsoAccountAssignment(ctx context.Context, conn *ssoadmin.SSOAdmin, principalID, principalType, targetID, permissionSetArn, instanceArn string) (*ssoadmin.AccountAssignment, error) {
+	accountAssignments, err := finder.AccountAssignments(ctx, conn, &ssoadmin.ListAccountAssignmentsInput{
+		AccountId:        aws.String(targetID),
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range accountAssignments {
+		if aws.StringValue(v.PrincipalType) == principalType && aws.StringValue(v.PrincipalId) == principalID {
+			return v, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// upperCaseStateFunc stores enum values in upper case, as returned by the API,
+// so that they can be configured case-insensitively.
+func upperCaseStateFunc(v interface{}) string {
+	return strings.ToUpper(v.(string))
+}
+
+// resourceAwsSsoAccountAssignmentImport imports an account assignment using its
+// resource ID, validating each part before Read looks up the assignment.
+func resourceAwsSsoAccountAssignmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts, err := parseSsoAccountAssignmentID(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	principalID := idParts[0]
+	principalType := idParts[1]
+	targetID := idParts[2]
+	targetType := idParts[3]
+	permissionSetArn := idParts[4]
+	instanceArn := idParts[5]
+
+	if principalType != ssoadmin.PrincipalTypeUser && principalType != ssoadmin.PrincipalTypeGroup {
+		return nil, fmt.Errorf("unexpected principal type (%s) in ID (%q), expected %s or %s", principalType, d.Id(), ssoadmin.PrincipalTypeUser, ssoadmin.PrincipalTypeGroup)
+	}
+
+	if _, errs := validateAwsAccountId(targetID, "target_id"); len(errs) > 0 {
+		return nil, fmt.Errorf("unexpected target ID in ID (%q): %w", d.Id(), errs[0])
+	}
+
+	if targetType != ssoadmin.TargetTypeAwsAccount {
+		return nil, fmt.Errorf("unexpected target type (%s) in ID (%q), expected %s", targetType, d.Id(), ssoadmin.TargetTypeAwsAccount)
+	}
+
+	if !arn.IsARN(permissionSetArn) {
+		return nil, fmt.Errorf("unexpected permission set ARN (%s) in ID (%q)", permissionSetArn, d.Id())
+	}
+
+	if !arn.IsARN(instanceArn) {
+		return nil, fmt.Errorf("unexpected instance ARN (%s) in ID (%q)", instanceArn, d.Id())
+	}
+
+	d.Set("instance_arn", instanceArn)
+	d.Set("permission_set_arn", permissionSetArn)
+	d.Set("principal_id", principalID)
+	d.Set("principal_type", principalType)
+	d.Set("target_id", targetID)
+	d.Set("target_type", targetType)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func parseSsoAccountAssignmentID(id string) ([]string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 6 {
+		return nil, fmt.Errorf("unexpected format for ID (%q), expected PRINCIPAL_ID,PRINCIPAL_TYPE,TARGET_ID,TARGET_TYPE,PERMISSION_SET_ARN,INSTANCE_ARN", id)
+	}
+
+	for _, idPart := range idParts {
+		if idPart == "" {
+			return nil, fmt.Errorf("unexpected format for ID (%q), expected PRINCIPAL_ID,PRINCIPAL_TYPE,TARGET_ID,TARGET_TYPE,PERMISSION_SET_ARN,INSTANCE_ARN", id)
+		}
+	}
+
+	return idParts, nil
+}
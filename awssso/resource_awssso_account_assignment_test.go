@@ -0,0 +1,454 @@
+package aws
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestResourceAwsSsoAccountAssignment_createTimeout(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"CreateAccountAssignment": {{Body: map[string]interface{}{
+			"AccountAssignmentCreationStatus": map[string]interface{}{
+				"RequestId": "11111111-2222-3333-4444-555555555555",
+				"Status":    ssoadmin.StatusValuesInProgress,
+			},
+		}}},
+		"DescribeAccountAssignmentCreationStatus": {{Body: map[string]interface{}{
+			"AccountAssignmentCreationStatus": map[string]interface{}{
+				"RequestId": "11111111-2222-3333-4444-555555555555",
+				"Status":    ssoadmin.StatusValuesInProgress,
+			},
+		}}},
+		"ListAccountAssignments": {{Body: map[string]interface{}{"AccountAssignments": []interface{}{}}}},
+	})
+
+	r := resourceAwsSsoAccountAssignment()
+
+	diff, err := testResourceDiff(r, nil, map[string]interface{}{
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		"principal_id":       "11111111-1111-1111-1111-111111111111",
+		"principal_type":     ssoadmin.PrincipalTypeUser,
+		"target_id":          "111111111111",
+		"timeouts": map[string]interface{}{
+			"create": "1s",
+		},
+	}, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	_, diags := r.Apply(context.Background(), nil, diff, client)
+
+	if !diags.HasError() {
+		t.Fatal("expected timeout error, got no error")
+	}
+
+	if expected := regexp.MustCompile(`to be created: (timeout while waiting for state|context deadline exceeded)`); !expected.MatchString(diags[0].Summary) {
+		t.Fatalf("expected error %s, got: %s", expected.String(), diags[0].Summary)
+	}
+}
+
+func TestResourceAwsSsoAccountAssignmentImport(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		ID            string
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName: "valid",
+			ID:       "11111111-1111-1111-1111-111111111111,USER,123456789012,AWS_ACCOUNT,arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111",
+		},
+		{
+			TestName:      "too short",
+			ID:            "11111111-1111-1111-1111-111111111111,USER,123456789012,AWS_ACCOUNT",
+			ExpectedError: regexp.MustCompile(`unexpected format for ID`),
+		},
+		{
+			TestName:      "invalid principal type",
+			ID:            "11111111-1111-1111-1111-111111111111,ROLE,123456789012,AWS_ACCOUNT,arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111",
+			ExpectedError: regexp.MustCompile(`unexpected principal type \(ROLE\)`),
+		},
+		{
+			TestName:      "invalid target ID",
+			ID:            "11111111-1111-1111-1111-111111111111,GROUP,1234,AWS_ACCOUNT,arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111",
+			ExpectedError: regexp.MustCompile(`unexpected target ID`),
+		},
+		{
+			TestName:      "invalid instance ARN",
+			ID:            "11111111-1111-1111-1111-111111111111,GROUP,123456789012,AWS_ACCOUNT,arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,ssoins-1111111111111111",
+			ExpectedError: regexp.MustCompile(`unexpected instance ARN`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAwsSsoAccountAssignment().Schema, map[string]interface{}{})
+			d.SetId(testCase.ID)
+
+			results, err := resourceAwsSsoAccountAssignmentImport(context.Background(), d, nil)
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil {
+				if !testCase.ExpectedError.MatchString(err.Error()) {
+					t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+				}
+
+				return
+			}
+
+			if got, expected := len(results), 1; got != expected {
+				t.Fatalf("got %d results, expected %d", got, expected)
+			}
+
+			for k, expected := range map[string]string{
+				"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+				"principal_id":       "11111111-1111-1111-1111-111111111111",
+				"principal_type":     "USER",
+				"target_id":          "123456789012",
+				"target_type":        "AWS_ACCOUNT",
+			} {
+				if got := results[0].Get(k).(string); got != expected {
+					t.Errorf("got %s %s, expected %s", k, got, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceAwsSsoAccountAssignment_enumCase(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		PrincipalType string
+		TargetType    string
+		ExpectedError bool
+	}{
+		{
+			TestName:      "upper case",
+			PrincipalType: "USER",
+			TargetType:    "AWS_ACCOUNT",
+		},
+		{
+			TestName:      "lower case",
+			PrincipalType: "user",
+			TargetType:    "aws_account",
+		},
+		{
+			TestName:      "unknown principal type",
+			PrincipalType: "ROLE",
+			TargetType:    "AWS_ACCOUNT",
+			ExpectedError: true,
+		},
+		{
+			TestName:      "unknown target type",
+			PrincipalType: "GROUP",
+			TargetType:    "ORGANIZATIONAL_UNIT",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			diags := resourceAwsSsoAccountAssignment().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+				"principal_id":       "11111111-1111-1111-1111-111111111111",
+				"principal_type":     testCase.PrincipalType,
+				"target_id":          "123456789012",
+				"target_type":        testCase.TargetType,
+			}))
+
+			if got := diags.HasError(); got != testCase.ExpectedError {
+				t.Errorf("got error %t, expected %t: %v", got, testCase.ExpectedError, diags)
+			}
+		})
+	}
+}
+
+func TestResourceAwsSsoAccountAssignment_normalizeCase(t *testing.T) {
+	status := map[string]interface{}{
+		"AccountAssignmentCreationStatus": map[string]interface{}{
+			"RequestId": "11111111-2222-3333-4444-555555555555",
+			"Status":    ssoadmin.StatusValuesSucceeded,
+		},
+	}
+
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreateAccountAssignment":                 {{Body: status}},
+		"DescribeAccountAssignmentCreationStatus": {{Body: status}},
+		"ListAccountAssignments": {{Body: map[string]interface{}{"AccountAssignments": []interface{}{}}}, {Body: map[string]interface{}{
+			"AccountAssignments": []interface{}{
+				map[string]interface{}{
+					"AccountId":        "123456789012",
+					"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+					"PrincipalId":      "11111111-1111-1111-1111-111111111111",
+					"PrincipalType":    "USER",
+				},
+			},
+		}}},
+	})
+
+	r := resourceAwsSsoAccountAssignment()
+	raw := map[string]interface{}{
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		"principal_id":       "11111111-1111-1111-1111-111111111111",
+		"principal_type":     "user",
+		"target_id":          "123456789012",
+		"target_type":        "aws_account",
+	}
+
+	state := testResourceApply(t, r, nil, raw, client)
+
+	creates := api.Requests("CreateAccountAssignment")
+
+	if got, expected := len(creates), 1; got != expected {
+		t.Fatalf("got %d CreateAccountAssignment calls, expected %d", got, expected)
+	}
+
+	for k, expected := range map[string]string{"PrincipalType": "USER", "TargetType": "AWS_ACCOUNT"} {
+		if got := creates[0].Body[k]; got != expected {
+			t.Errorf("got %s %v, expected %s", k, got, expected)
+		}
+	}
+
+	if got, expected := state.Attributes["principal_type"], "USER"; got != expected {
+		t.Errorf("got principal_type %s, expected %s", got, expected)
+	}
+
+	diff, err := testResourceDiff(r, state, raw, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff, got: %v", diff)
+	}
+}
+
+func TestResourceAwsSsoAccountAssignment_targetAccountID(t *testing.T) {
+	testCases := []struct {
+		TestName            string
+		AllowedAccountIds   []string
+		ForbiddenAccountIds []string
+		ExpectedError       *regexp.Regexp
+	}{
+		{
+			TestName:          "allowed",
+			AllowedAccountIds: []string{"123456789012"},
+		},
+		{
+			TestName:            "forbidden",
+			ForbiddenAccountIds: []string{"123456789012"},
+			ExpectedError:       regexp.MustCompile(`Forbidden AWS Account ID: 123456789012`),
+		},
+		{
+			TestName:          "unlisted",
+			AllowedAccountIds: []string{"210987654321"},
+			ExpectedError:     regexp.MustCompile(`AWS Account ID not allowed: 123456789012`),
+		},
+	}
+
+	status := map[string]interface{}{
+		"AccountAssignmentCreationStatus": map[string]interface{}{
+			"RequestId": "11111111-2222-3333-4444-555555555555",
+			"Status":    ssoadmin.StatusValuesSucceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"CreateAccountAssignment":                 {{Body: status}},
+				"DescribeAccountAssignmentCreationStatus": {{Body: status}},
+				"ListAccountAssignments": {{Body: map[string]interface{}{"AccountAssignments": []interface{}{}}}, {Body: map[string]interface{}{
+					"AccountAssignments": []interface{}{
+						map[string]interface{}{
+							"AccountId":        "123456789012",
+							"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+							"PrincipalId":      "11111111-1111-1111-1111-111111111111",
+							"PrincipalType":    "USER",
+						},
+					},
+				}}},
+			})
+
+			client.allowedAccountIds = testCase.AllowedAccountIds
+			client.forbiddenAccountIds = testCase.ForbiddenAccountIds
+
+			r := resourceAwsSsoAccountAssignment()
+
+			diff, err := testResourceDiff(r, nil, map[string]interface{}{
+				"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+				"principal_id":       "11111111-1111-1111-1111-111111111111",
+				"principal_type":     ssoadmin.PrincipalTypeUser,
+				"target_id":          "123456789012",
+			}, client)
+
+			if err != nil {
+				t.Fatalf("error planning resource: %s", err)
+			}
+
+			_, diags := r.Apply(context.Background(), nil, diff, client)
+
+			if testCase.ExpectedError == nil {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+
+				if got, expected := len(api.Requests("CreateAccountAssignment")), 1; got != expected {
+					t.Errorf("got %d CreateAccountAssignment calls, expected %d", got, expected)
+				}
+
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatal("expected error, got no error")
+			}
+
+			if !testCase.ExpectedError.MatchString(diags[0].Summary) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+			}
+
+			if got := len(api.Requests("CreateAccountAssignment")); got != 0 {
+				t.Errorf("got %d CreateAccountAssignment calls, expected none", got)
+			}
+		})
+	}
+}
+
+func TestResourceAwsSsoAccountAssignment_createExisting(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ListAccountAssignments": {{Body: map[string]interface{}{
+			"AccountAssignments": []interface{}{
+				map[string]interface{}{
+					"AccountId":        "123456789012",
+					"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+					"PrincipalId":      "11111111-1111-1111-1111-111111111111",
+					"PrincipalType":    "USER",
+				},
+			},
+		}}},
+	})
+
+	r := resourceAwsSsoAccountAssignment()
+
+	diff, err := testResourceDiff(r, nil, map[string]interface{}{
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		"principal_id":       "11111111-1111-1111-1111-111111111111",
+		"principal_type":     ssoadmin.PrincipalTypeUser,
+		"target_id":          "123456789012",
+	}, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	_, diags := r.Apply(context.Background(), nil, diff, client)
+
+	if !diags.HasError() {
+		t.Fatal("expected error, got no error")
+	}
+
+	if expected := regexp.MustCompile(`already exists, import it with ID 11111111-1111-1111-1111-111111111111,USER,123456789012,AWS_ACCOUNT,`); !expected.MatchString(diags[0].Summary) {
+		t.Fatalf("expected error %s, got: %s", expected.String(), diags[0].Summary)
+	}
+
+	if got := len(api.Requests("CreateAccountAssignment")); got != 0 {
+		t.Errorf("got %d CreateAccountAssignment calls, expected none", got)
+	}
+}
+
+func TestResourceAwsSsoAccountAssignment_deleteAlreadyDeleted(t *testing.T) {
+	assigned := mockapi.Response{Body: map[string]interface{}{
+		"AccountAssignments": []interface{}{
+			map[string]interface{}{
+				"AccountId":        "111111111111",
+				"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+				"PrincipalId":      "11111111-1111-1111-1111-111111111111",
+				"PrincipalType":    ssoadmin.PrincipalTypeUser,
+			},
+		},
+	}}
+	unassigned := mockapi.Response{Body: map[string]interface{}{"AccountAssignments": []interface{}{}}}
+
+	testCases := []struct {
+		TestName                    string
+		Responses                   map[string][]mockapi.Response
+		ExpectedDeleteCalls         int
+		ExpectedDeletionStatusCalls int
+	}{
+		{
+			TestName: "already absent",
+			Responses: map[string][]mockapi.Response{
+				"ListAccountAssignments": {unassigned},
+			},
+		},
+		{
+			TestName: "delete not found",
+			Responses: map[string][]mockapi.Response{
+				"DeleteAccountAssignment": {{ErrorCode: ssoadmin.ErrCodeResourceNotFoundException}},
+				"ListAccountAssignments":  {assigned},
+			},
+			ExpectedDeleteCalls: 1,
+		},
+		{
+			TestName: "deletion status failed",
+			Responses: map[string][]mockapi.Response{
+				"DeleteAccountAssignment": {{Body: map[string]interface{}{
+					"AccountAssignmentDeletionStatus": map[string]interface{}{
+						"RequestId": "11111111-2222-3333-4444-555555555555",
+						"Status":    ssoadmin.StatusValuesInProgress,
+					},
+				}}},
+				"DescribeAccountAssignmentDeletionStatus": {{Body: map[string]interface{}{
+					"AccountAssignmentDeletionStatus": map[string]interface{}{
+						"FailureReason": "Received a 404 status error: Not supported account assignment",
+						"RequestId":     "11111111-2222-3333-4444-555555555555",
+						"Status":        ssoadmin.StatusValuesFailed,
+					},
+				}}},
+				"ListAccountAssignments": {assigned, unassigned},
+			},
+			ExpectedDeleteCalls:         1,
+			ExpectedDeletionStatusCalls: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, testCase.Responses)
+
+			testResourceDestroy(t, resourceAwsSsoAccountAssignment(), &terraform.InstanceState{
+				ID: "11111111-1111-1111-1111-111111111111,USER,111111111111,AWS_ACCOUNT,arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111",
+			}, client)
+
+			if got, expected := len(api.Requests("DeleteAccountAssignment")), testCase.ExpectedDeleteCalls; got != expected {
+				t.Errorf("got %d DeleteAccountAssignment calls, expected %d", got, expected)
+			}
+
+			if got, expected := len(api.Requests("DescribeAccountAssignmentDeletionStatus")), testCase.ExpectedDeletionStatusCalls; got != expected {
+				t.Errorf("got %d DescribeAccountAssignmentDeletionStatus calls, expected %d", got, expected)
+			}
+		})
+	}
+}
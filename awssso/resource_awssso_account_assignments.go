@@ -0,0 +1,382 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/waiter"
+)
+
+func resourceAwsSsoAccountAssignments() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoAccountAssignmentsCreate,
+		ReadContext:   resourceAwsSsoAccountAssignmentsRead,
+		UpdateContext: resourceAwsSsoAccountAssignmentsUpdate,
+		DeleteContext: resourceAwsSsoAccountAssignmentsDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(waiter.AccountAssignmentCreatedTimeout),
+			Update: schema.DefaultTimeout(waiter.AccountAssignmentCreatedTimeout),
+			Delete: schema.DefaultTimeout(waiter.AccountAssignmentDeletedTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateAwsAccountId,
+				},
+			},
+			"account_status": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"principal_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSsoPrincipalId,
+			},
+			"principal_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    upperCaseStateFunc,
+				ValidateFunc: validation.StringInSlice(ssoadmin.PrincipalType_Values(), true),
+			},
+			"rollback_on_error": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceAwsSsoAccountAssignmentsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn, err := ssoInstanceArn(ctx, d, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissionSetArn := d.Get("permission_set_arn").(string)
+	principalID := d.Get("principal_id").(string)
+	principalType := upperCaseStateFunc(d.Get("principal_type"))
+
+	assigned, failed, diags := resourceAwsSsoAccountAssignmentsAssign(ctx, d, meta, expandStringSet(d.Get("account_ids").(*schema.Set)), d.Timeout(schema.TimeoutCreate))
+
+	if len(assigned) == 0 {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s,%s,%s", principalID, principalType, permissionSetArn, instanceArn))
+
+	// Only the accounts assigned are recorded, so that the next plan shows
+	// just the accounts that failed.
+	if err := d.Set("account_ids", assigned); err != nil {
+		return append(diags, diag.FromErr(fmt.Errorf("error setting account_ids: %w", err))...)
+	}
+
+	if err := d.Set("account_status", flattenSsoAccountAssignmentsStatus(assigned, failed)); err != nil {
+		return append(diags, diag.FromErr(fmt.Errorf("error setting account_status: %w", err))...)
+	}
+
+	// Terraform taints a resource when Create returns an error, and replacing
+	// it on the next apply would unassign the accounts that succeeded, so the
+	// failures are reported as warnings instead. The accounts left over by a
+	// failed rollback are the exception, as they are meant to be removed.
+	if diags.HasError() && !d.Get("rollback_on_error").(bool) {
+		for i := range diags {
+			diags[i].Severity = diag.Warning
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceAwsSsoAccountAssignmentsRead(ctx, d, meta)...)
+}
+
+func resourceAwsSsoAccountAssignmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	principalID, principalType, permissionSetArn, instanceArn, err := parseSsoAccountAssignmentsID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var accountIDs []string
+
+	for _, accountID := range expandStringSet(d.Get("account_ids").(*schema.Set)) {
+		accountAssignment, err := findSsoAccountAssignment(ctx, conn, principalID, principalType, accountID, permissionSetArn, instanceArn)
+
+		if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+			log.Printf("[WARN] SSO Account Assignments (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading SSO Account Assignments (%s) for account (%s): %w", d.Id(), accountID, cleanAwsRequestError(err)))
+		}
+
+		if accountAssignment != nil {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+
+	if len(accountIDs) == 0 && !d.IsNewResource() {
+		log.Printf("[WARN] SSO Account Assignments (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("account_ids", accountIDs); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting account_ids: %w", err))
+	}
+
+	// Accounts that failed to be assigned are not in account_ids, so their
+	// status is kept from the last create or update.
+	var failed []string
+
+	for accountID, status := range d.Get("account_status").(map[string]interface{}) {
+		if status == ssoadmin.StatusValuesFailed && !d.Get("account_ids").(*schema.Set).Contains(accountID) {
+			failed = append(failed, accountID)
+		}
+	}
+
+	if err := d.Set("account_status", flattenSsoAccountAssignmentsStatus(accountIDs, failed)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting account_status: %w", err))
+	}
+
+	d.Set("instance_arn", instanceArn)
+	d.Set("permission_set_arn", permissionSetArn)
+	d.Set("principal_id", principalID)
+	d.Set("principal_type", principalType)
+
+	return nil
+}
+
+func resourceAwsSsoAccountAssignmentsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("account_ids") {
+		return resourceAwsSsoAccountAssignmentsRead(ctx, d, meta)
+	}
+
+	o, n := d.GetChange("account_ids")
+	oldAccountIDs := o.(*schema.Set)
+	newAccountIDs := n.(*schema.Set)
+	removed := oldAccountIDs.Difference(newAccountIDs)
+
+	unassigned, diags := resourceAwsSsoAccountAssignmentsUnassign(ctx, d, meta, expandStringSet(removed), d.Timeout(schema.TimeoutUpdate))
+	assigned, failed, assignDiags := resourceAwsSsoAccountAssignmentsAssign(ctx, d, meta, expandStringSet(newAccountIDs.Difference(oldAccountIDs)), d.Timeout(schema.TimeoutUpdate))
+	diags = append(diags, assignDiags...)
+
+	if err := d.Set("account_status", flattenSsoAccountAssignmentsStatus(nil, failed)); err != nil {
+		return append(diags, diag.FromErr(fmt.Errorf("error setting account_status: %w", err))...)
+	}
+
+	if diags.HasError() {
+		// Record the accounts actually assigned, keeping those that failed to
+		// be unassigned and leaving out those that failed to be assigned.
+		accountIDs := oldAccountIDs.Intersection(newAccountIDs).Union(removed)
+
+		for _, accountID := range unassigned {
+			accountIDs.Remove(accountID)
+		}
+
+		for _, accountID := range assigned {
+			accountIDs.Add(accountID)
+		}
+
+		if err := d.Set("account_ids", accountIDs); err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error setting account_ids: %w", err))...)
+		}
+
+		if err := d.Set("account_status", flattenSsoAccountAssignmentsStatus(expandStringSet(accountIDs), failed)); err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error setting account_status: %w", err))...)
+		}
+
+		return diags
+	}
+
+	return append(diags, resourceAwsSsoAccountAssignmentsRead(ctx, d, meta)...)
+}
+
+func resourceAwsSsoAccountAssignmentsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, diags := resourceAwsSsoAccountAssignmentsUnassign(ctx, d, meta, expandStringSet(d.Get("account_ids").(*schema.Set)), d.Timeout(schema.TimeoutDelete))
+
+	return diags
+}
+
+// resourceAwsSsoAccountAssignmentsAssign assigns the permission set to the
+// principal in each account, returning the accounts assigned and the accounts
+// that failed, along with an error for each failure. Failures do not stop the
+// remaining accounts from being assigned. With rollback_on_error, the accounts
+// assigned are unassigned again when any account fails.
+func resourceAwsSsoAccountAssignmentsAssign(ctx context.Context, d *schema.ResourceData, meta interface{}, accountIDs []string, timeout time.Duration) ([]string, []string, diag.Diagnostics) {
+	client := meta.(*AWSClient)
+	conn := client.SSOAdminConn()
+
+	instanceArn := d.Get("instance_arn").(string)
+	permissionSetArn := d.Get("permission_set_arn").(string)
+	principalID := d.Get("principal_id").(string)
+	principalType := upperCaseStateFunc(d.Get("principal_type"))
+
+	var assigned, failed []string
+	var diags diag.Diagnostics
+
+	for _, accountID := range accountIDs {
+		if err := client.ValidateTargetAccountID(accountID); err != nil {
+			diags = append(diags, diag.FromErr(fmt.Errorf("error assigning account (%s): %w", accountID, err))...)
+			failed = append(failed, accountID)
+			continue
+		}
+
+		input := &ssoadmin.CreateAccountAssignmentInput{
+			InstanceArn:      aws.String(instanceArn),
+			PermissionSetArn: aws.String(permissionSetArn),
+			PrincipalId:      aws.String(principalID),
+			PrincipalType:    aws.String(principalType),
+			TargetId:         aws.String(accountID),
+			TargetType:       aws.String(ssoadmin.TargetTypeAwsAccount),
+		}
+
+		if err := createSsoAccountAssignment(ctx, conn, input, timeout); err != nil {
+			diags = append(diags, diag.FromErr(fmt.Errorf("error assigning account (%s): %w", accountID, err))...)
+			failed = append(failed, accountID)
+			continue
+		}
+
+		assigned = append(assigned, accountID)
+	}
+
+	if !diags.HasError() || !d.Get("rollback_on_error").(bool) {
+		return assigned, failed, diags
+	}
+
+	log.Printf("[DEBUG] Rolling back SSO Account Assignments for %s (%s) to accounts: %s", principalType, principalID, strings.Join(assigned, ", "))
+
+	unassigned, rollbackDiags := resourceAwsSsoAccountAssignmentsUnassign(ctx, d, meta, assigned, timeout)
+	diags = append(diags, rollbackDiags...)
+
+	remaining := schema.NewSet(schema.HashString, nil)
+
+	for _, accountID := range assigned {
+		remaining.Add(accountID)
+	}
+
+	for _, accountID := range unassigned {
+		remaining.Remove(accountID)
+	}
+
+	return expandStringSet(remaining), failed, diags
+}
+
+// resourceAwsSsoAccountAssignmentsUnassign removes the assignment of the
+// permission set to the principal from each account, returning the accounts
+// unassigned along with an error for each account that failed.
+func resourceAwsSsoAccountAssignmentsUnassign(ctx context.Context, d *schema.ResourceData, meta interface{}, accountIDs []string, timeout time.Duration) ([]string, diag.Diagnostics) {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn := d.Get("instance_arn").(string)
+	permissionSetArn := d.Get("permission_set_arn").(string)
+	principalID := d.Get("principal_id").(string)
+	principalType := upperCaseStateFunc(d.Get("principal_type"))
+
+	var unassigned []string
+	var diags diag.Diagnostics
+
+	for _, accountID := range accountIDs {
+		input := &ssoadmin.DeleteAccountAssignmentInput{
+			InstanceArn:      aws.String(instanceArn),
+			PermissionSetArn: aws.String(permissionSetArn),
+			PrincipalId:      aws.String(principalID),
+			PrincipalType:    aws.String(principalType),
+			TargetId:         aws.String(accountID),
+			TargetType:       aws.String(ssoadmin.TargetTypeAwsAccount),
+		}
+
+		id := fmt.Sprintf("%s,%s,%s,%s,%s,%s", principalID, principalType, accountID, ssoadmin.TargetTypeAwsAccount, permissionSetArn, instanceArn)
+
+		if err := deleteSsoAccountAssignment(ctx, conn, input, id, timeout); err != nil {
+			diags = append(diags, diag.FromErr(fmt.Errorf("error unassigning account (%s): %w", accountID, err))...)
+			continue
+		}
+
+		unassigned = append(unassigned, accountID)
+	}
+
+	return unassigned, diags
+}
+
+// flattenSsoAccountAssignmentsStatus returns the account_status map of
+// assigned and failed accounts.
+func flattenSsoAccountAssignmentsStatus(assigned, failed []string) map[string]string {
+	result := make(map[string]string, len(assigned)+len(failed))
+
+	for _, accountID := range failed {
+		result[accountID] = ssoadmin.StatusValuesFailed
+	}
+
+	for _, accountID := range assigned {
+		result[accountID] = ssoadmin.StatusValuesSucceeded
+	}
+
+	return result
+}
+
+func parseSsoAccountAssignmentsID(id string) (string, string, string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 4 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" || idParts[3] == "" {
+		return "", "", "", "", fmt.Errorf("unexpected format for ID (%q), expected PRINCIPAL_ID,PRINCIPAL_TYPE,PERMISSION_SET_ARN,INSTANCE_ARN", id)
+	}
+
+	return idParts[0], idParts[1], idParts[2], idParts[3], nil
+}
+
+// expandStringSet returns the sorted strings of a set, so that accounts are
+// processed in a stable order.
+func expandStringSet(s *schema.Set) []string {
+	results := make([]string, 0, s.Len())
+
+	for _, v := range s.List() {
+		results = append(results, v.(string))
+	}
+
+	sort.Strings(results)
+
+	return results
+}
@@ -0,0 +1,214 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func testAccountAssignmentsResponses() map[string][]mockapi.Response {
+	creationStatus := map[string]interface{}{
+		"AccountAssignmentCreationStatus": map[string]interface{}{
+			"RequestId": "11111111-2222-3333-4444-555555555555",
+			"Status":    ssoadmin.StatusValuesSucceeded,
+		},
+	}
+	deletionStatus := map[string]interface{}{
+		"AccountAssignmentDeletionStatus": map[string]interface{}{
+			"RequestId": "66666666-7777-8888-9999-000000000000",
+			"Status":    ssoadmin.StatusValuesSucceeded,
+		},
+	}
+
+	return map[string][]mockapi.Response{
+		"CreateAccountAssignment": {
+			{Body: creationStatus},
+			{ErrorCode: ssoadmin.ErrCodeAccessDeniedException, StatusCode: 400},
+			{Body: creationStatus},
+		},
+		"DeleteAccountAssignment":                 {{Body: deletionStatus}},
+		"DescribeAccountAssignmentCreationStatus": {{Body: creationStatus}},
+		"DescribeAccountAssignmentDeletionStatus": {{Body: deletionStatus}},
+		"ListAccountAssignments": {{Body: map[string]interface{}{
+			"AccountAssignments": []interface{}{
+				map[string]interface{}{"PrincipalId": "11111111-1111-1111-1111-111111111111", "PrincipalType": "GROUP"},
+			},
+		}}},
+	}
+}
+
+func testAccountAssignmentsConfig(rollbackOnError bool) map[string]interface{} {
+	return map[string]interface{}{
+		"account_ids":        []interface{}{"111111111111", "222222222222", "333333333333"},
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		"principal_id":       "11111111-1111-1111-1111-111111111111",
+		"principal_type":     "GROUP",
+		"rollback_on_error":  rollbackOnError,
+	}
+}
+
+func TestResourceAwsSsoAccountAssignments_partialFailure(t *testing.T) {
+	client, api := testMockClient(t, testAccountAssignmentsResponses())
+
+	r := resourceAwsSsoAccountAssignments()
+
+	diff, err := testResourceDiff(r, nil, testAccountAssignmentsConfig(false), client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	state, diags := r.Apply(context.Background(), nil, diff, client)
+
+	if diags.HasError() {
+		t.Fatalf("expected the failed account to be reported as a warning, got: %v", diags)
+	}
+
+	if got, expected := len(diags), 1; got != expected {
+		t.Fatalf("got %d diagnostics, expected %d: %v", got, expected, diags)
+	}
+
+	if got, expected := diags[0].Severity, diag.Warning; got != expected {
+		t.Errorf("got severity %v, expected %v", got, expected)
+	}
+
+	if expected := regexp.MustCompile(`error assigning account \(222222222222\): error creating SSO Account Assignment for GROUP`); !expected.MatchString(diags[0].Summary) {
+		t.Errorf("expected error %s, got: %s", expected.String(), diags[0].Summary)
+	}
+
+	if got, expected := len(api.Requests("CreateAccountAssignment")), 3; got != expected {
+		t.Errorf("got %d CreateAccountAssignment calls, expected %d", got, expected)
+	}
+
+	if got := len(api.Requests("DeleteAccountAssignment")); got != 0 {
+		t.Errorf("got %d DeleteAccountAssignment calls, expected none", got)
+	}
+
+	if state == nil || state.ID == "" {
+		t.Fatal("expected the assigned accounts to be recorded in state")
+	}
+
+	d := r.Data(state)
+
+	if got, expected := expandStringSet(d.Get("account_ids").(*schema.Set)), []string{"111111111111", "333333333333"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got account_ids %v, expected %v", got, expected)
+	}
+
+	expectedStatus := map[string]interface{}{
+		"111111111111": ssoadmin.StatusValuesSucceeded,
+		"222222222222": ssoadmin.StatusValuesFailed,
+		"333333333333": ssoadmin.StatusValuesSucceeded,
+	}
+
+	if got := d.Get("account_status").(map[string]interface{}); !reflect.DeepEqual(got, expectedStatus) {
+		t.Errorf("got account_status %v, expected %v", got, expectedStatus)
+	}
+
+	diff, err = testResourceDiff(r, state, testAccountAssignmentsConfig(false), client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	if diff.RequiresNew() {
+		t.Errorf("expected the failed account to be assigned in place, got replacement: %#v", diff.Attributes)
+	}
+
+	var added []string
+
+	for k, attr := range diff.Attributes {
+		if strings.HasPrefix(k, "account_ids.") && k != "account_ids.#" && attr.Old == "" {
+			added = append(added, attr.New)
+		}
+	}
+
+	if expected := []string{"222222222222"}; !reflect.DeepEqual(added, expected) {
+		t.Errorf("got planned account_ids additions %v, expected %v", added, expected)
+	}
+}
+
+func TestResourceAwsSsoAccountAssignments_rollbackOnError(t *testing.T) {
+	client, api := testMockClient(t, testAccountAssignmentsResponses())
+
+	r := resourceAwsSsoAccountAssignments()
+
+	diff, err := testResourceDiff(r, nil, testAccountAssignmentsConfig(true), client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	state, diags := r.Apply(context.Background(), nil, diff, client)
+
+	if !diags.HasError() {
+		t.Fatal("expected error, got no error")
+	}
+
+	deletes := api.Requests("DeleteAccountAssignment")
+
+	if got, expected := len(deletes), 2; got != expected {
+		t.Fatalf("got %d DeleteAccountAssignment calls, expected %d", got, expected)
+	}
+
+	for i, expected := range []string{"111111111111", "333333333333"} {
+		if got := deletes[i].Body["TargetId"]; got != expected {
+			t.Errorf("got rolled back TargetId %v, expected %s", got, expected)
+		}
+	}
+
+	if state != nil && state.ID != "" {
+		t.Errorf("expected no resource in state after rollback, got ID: %s", state.ID)
+	}
+}
+
+func TestResourceAwsSsoAccountAssignments_update(t *testing.T) {
+	responses := testAccountAssignmentsResponses()
+	responses["CreateAccountAssignment"] = responses["CreateAccountAssignment"][:1]
+
+	client, api := testMockClient(t, responses)
+
+	r := resourceAwsSsoAccountAssignments()
+
+	config := testAccountAssignmentsConfig(false)
+	config["account_ids"] = []interface{}{"111111111111"}
+
+	state := testResourceApply(t, r, nil, config, client)
+
+	config["account_ids"] = []interface{}{"444444444444"}
+
+	state = testResourceApply(t, r, state, config, client)
+
+	if got, expected := len(api.Requests("CreateAccountAssignment")), 2; got != expected {
+		t.Errorf("got %d CreateAccountAssignment calls, expected %d", got, expected)
+	}
+
+	deletes := api.Requests("DeleteAccountAssignment")
+
+	if got, expected := len(deletes), 1; got != expected {
+		t.Fatalf("got %d DeleteAccountAssignment calls, expected %d", got, expected)
+	}
+
+	if got, expected := deletes[0].Body["TargetId"], "111111111111"; got != expected {
+		t.Errorf("got TargetId %v, expected %s", got, expected)
+	}
+
+	if got, expected := expandStringSet(r.Data(state).Get("account_ids").(*schema.Set)), []string{"444444444444"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got account_ids %v, expected %v", got, expected)
+	}
+
+	expectedStatus := map[string]interface{}{
+		"444444444444": ssoadmin.StatusValuesSucceeded,
+	}
+
+	if got := r.Data(state).Get("account_status").(map[string]interface{}); !reflect.DeepEqual(got, expectedStatus) {
+		t.Errorf("got account_status %v, expected %v", got, expectedStatus)
+	}
+}
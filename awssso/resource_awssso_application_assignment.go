@@ -0,0 +1,139 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAwsSsoApplicationAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoApplicationAssignmentCreate,
+		ReadContext:   resourceAwsSsoApplicationAssignmentRead,
+		DeleteContext: resourceAwsSsoApplicationAssignmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"application_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"principal_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 47),
+			},
+			"principal_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    upperCaseStateFunc,
+				ValidateFunc: validation.StringInSlice(ssoadmin.PrincipalType_Values(), true),
+			},
+		},
+	}
+}
+
+func resourceAwsSsoApplicationAssignmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	applicationArn := d.Get("application_arn").(string)
+	principalID := d.Get("principal_id").(string)
+	principalType := upperCaseStateFunc(d.Get("principal_type"))
+
+	_, err := conn.CreateApplicationAssignmentWithContext(ctx, &ssoadmin.CreateApplicationAssignmentInput{
+		ApplicationArn: aws.String(applicationArn),
+		PrincipalId:    aws.String(principalID),
+		PrincipalType:  aws.String(principalType),
+	})
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating SSO Application (%s) Assignment for %s (%s): %w", applicationArn, principalType, principalID, cleanAwsRequestError(err)))
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s,%s", principalID, principalType, applicationArn))
+
+	return resourceAwsSsoApplicationAssignmentRead(ctx, d, meta)
+}
+
+func resourceAwsSsoApplicationAssignmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	principalID, principalType, applicationArn, err := parseSsoApplicationAssignmentID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	output, err := conn.DescribeApplicationAssignmentWithContext(ctx, &ssoadmin.DescribeApplicationAssignmentInput{
+		ApplicationArn: aws.String(applicationArn),
+		PrincipalId:    aws.String(principalID),
+		PrincipalType:  aws.String(principalType),
+	})
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] SSO Application Assignment (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading SSO Application Assignment (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	if output == nil {
+		return diag.Errorf("error reading SSO Application Assignment (%s): empty output", d.Id())
+	}
+
+	d.Set("application_arn", output.ApplicationArn)
+	d.Set("principal_id", output.PrincipalId)
+	d.Set("principal_type", output.PrincipalType)
+
+	return nil
+}
+
+func resourceAwsSsoApplicationAssignmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	principalID, principalType, applicationArn, err := parseSsoApplicationAssignmentID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = conn.DeleteApplicationAssignmentWithContext(ctx, &ssoadmin.DeleteApplicationAssignmentInput{
+		ApplicationArn: aws.String(applicationArn),
+		PrincipalId:    aws.String(principalID),
+		PrincipalType:  aws.String(principalType),
+	})
+
+	if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting SSO Application Assignment (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	return nil
+}
+
+func parseSsoApplicationAssignmentID(id string) (string, string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format for ID (%q), expected PRINCIPAL_ID,PRINCIPAL_TYPE,APPLICATION_ARN", id)
+	}
+
+	return idParts[0], idParts[1], idParts[2], nil
+}
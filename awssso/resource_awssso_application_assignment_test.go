@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestResourceAwsSsoApplicationAssignment_lifecycle(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreateApplicationAssignment": {{}},
+		"DescribeApplicationAssignment": {{Body: map[string]interface{}{
+			"ApplicationArn": "arn:aws:sso::123456789012:application/ssoins-1111111111111111/apl-1111111111111111",
+			"PrincipalId":    "11111111-1111-1111-1111-111111111111",
+			"PrincipalType":  "GROUP",
+		}}},
+		"DeleteApplicationAssignment": {{}},
+	})
+
+	r := resourceAwsSsoApplicationAssignment()
+	state := testResourceApply(t, r, nil, map[string]interface{}{
+		"application_arn": "arn:aws:sso::123456789012:application/ssoins-1111111111111111/apl-1111111111111111",
+		"principal_id":    "11111111-1111-1111-1111-111111111111",
+		"principal_type":  "group",
+	}, client)
+
+	if got, expected := state.ID, "11111111-1111-1111-1111-111111111111,GROUP,arn:aws:sso::123456789012:application/ssoins-1111111111111111/apl-1111111111111111"; got != expected {
+		t.Fatalf("got ID %s, expected %s", got, expected)
+	}
+
+	creates := api.Requests("CreateApplicationAssignment")
+
+	if got, expected := len(creates), 1; got != expected {
+		t.Fatalf("got %d CreateApplicationAssignment calls, expected %d", got, expected)
+	}
+
+	if got, expected := creates[0].Body["PrincipalType"], "GROUP"; got != expected {
+		t.Errorf("got PrincipalType %v, expected %s", got, expected)
+	}
+
+	testResourceDestroy(t, r, state, client)
+
+	deletes := api.Requests("DeleteApplicationAssignment")
+
+	if got, expected := len(deletes), 1; got != expected {
+		t.Fatalf("got %d DeleteApplicationAssignment calls, expected %d", got, expected)
+	}
+
+	if got, expected := deletes[0].Body["PrincipalId"], "11111111-1111-1111-1111-111111111111"; got != expected {
+		t.Errorf("got PrincipalId %v, expected %s", got, expected)
+	}
+}
+
+func TestResourceAwsSsoApplicationAssignment_drift(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"DescribeApplicationAssignment": {{ErrorCode: ssoadmin.ErrCodeResourceNotFoundException}},
+	})
+
+	r := resourceAwsSsoApplicationAssignment()
+	d := r.Data(&terraform.InstanceState{
+		ID: "11111111-1111-1111-1111-111111111111,GROUP,arn:aws:sso::123456789012:application/ssoins-1111111111111111/apl-1111111111111111",
+	})
+
+	if diags := resourceAwsSsoApplicationAssignmentRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading resource: %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected deleted assignment to be removed from state, got ID: %s", d.Id())
+	}
+}
@@ -0,0 +1,215 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+)
+
+func resourceAwsSsoCustomerManagedPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoCustomerManagedPolicyAttachmentCreate,
+		ReadContext:   resourceAwsSsoCustomerManagedPolicyAttachmentRead,
+		DeleteContext: resourceAwsSsoCustomerManagedPolicyAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"customer_managed_policy_reference": customerManagedPolicyReferenceSchema(),
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func customerManagedPolicyReferenceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringLenBetween(1, 128),
+				},
+				"path": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      "/",
+					ValidateFunc: validation.StringLenBetween(1, 512),
+				},
+			},
+		},
+	}
+}
+
+func resourceAwsSsoCustomerManagedPolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn, err := ssoInstanceArn(ctx, d, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissionSetArn := d.Get("permission_set_arn").(string)
+	reference := expandSsoCustomerManagedPolicyReference(d.Get("customer_managed_policy_reference").([]interface{}))
+
+	input := &ssoadmin.AttachCustomerManagedPolicyReferenceToPermissionSetInput{
+		CustomerManagedPolicyReference: reference,
+		InstanceArn:                    aws.String(instanceArn),
+		PermissionSetArn:               aws.String(permissionSetArn),
+	}
+
+	_, err = conn.AttachCustomerManagedPolicyReferenceToPermissionSetWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error attaching Customer Managed Policy (%s) to SSO Permission Set (%s): %w", aws.StringValue(reference.Name), permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s,%s,%s", aws.StringValue(reference.Name), aws.StringValue(reference.Path), permissionSetArn, instanceArn))
+
+	diags := provisionPermissionSet(ctx, meta, instanceArn, permissionSetArn)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceAwsSsoCustomerManagedPolicyAttachmentRead(ctx, d, meta)...)
+}
+
+func resourceAwsSsoCustomerManagedPolicyAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	name, path, permissionSetArn, instanceArn, err := parseSsoCustomerManagedPolicyAttachmentID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	reference, err := finder.CustomerManagedPolicy(ctx, conn, name, path, permissionSetArn, instanceArn)
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] Customer Managed Policy (%s) for SSO Permission Set (%s) not found, removing from state", name, permissionSetArn)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Customer Managed Policy (%s) for SSO Permission Set (%s): %w", name, permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	if reference == nil {
+		if d.IsNewResource() {
+			return diag.Errorf("error reading Customer Managed Policy (%s) for SSO Permission Set (%s): not found", name, permissionSetArn)
+		}
+
+		log.Printf("[WARN] Customer Managed Policy (%s) for SSO Permission Set (%s) not found, removing from state", name, permissionSetArn)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("customer_managed_policy_reference", flattenSsoCustomerManagedPolicyReference(reference)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting customer_managed_policy_reference: %w", err))
+	}
+
+	d.Set("instance_arn", instanceArn)
+	d.Set("permission_set_arn", permissionSetArn)
+
+	return nil
+}
+
+func resourceAwsSsoCustomerManagedPolicyAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	name, path, permissionSetArn, instanceArn, err := parseSsoCustomerManagedPolicyAttachmentID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &ssoadmin.DetachCustomerManagedPolicyReferenceFromPermissionSetInput{
+		CustomerManagedPolicyReference: &ssoadmin.CustomerManagedPolicyReference{
+			Name: aws.String(name),
+			Path: aws.String(path),
+		},
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}
+
+	_, err = conn.DetachCustomerManagedPolicyReferenceFromPermissionSetWithContext(ctx, input)
+
+	if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error detaching Customer Managed Policy (%s) from SSO Permission Set (%s): %w", name, permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	return provisionPermissionSet(ctx, meta, instanceArn, permissionSetArn)
+}
+
+func expandSsoCustomerManagedPolicyReference(l []interface{}) *ssoadmin.CustomerManagedPolicyReference {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	reference := &ssoadmin.CustomerManagedPolicyReference{
+		Name: aws.String(m["name"].(string)),
+	}
+
+	if v, ok := m["path"].(string); ok && v != "" {
+		reference.Path = aws.String(v)
+	}
+
+	return reference
+}
+
+func flattenSsoCustomerManagedPolicyReference(reference *ssoadmin.CustomerManagedPolicyReference) []interface{} {
+	if reference == nil {
+		return []interface{}{}
+	}
+
+	m := map[string]interface{}{
+		"name": aws.StringValue(reference.Name),
+		"path": aws.StringValue(reference.Path),
+	}
+
+	return []interface{}{m}
+}
+
+func parseSsoCustomerManagedPolicyAttachmentID(id string) (string, string, string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 4 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" || idParts[3] == "" {
+		return "", "", "", "", fmt.Errorf("unexpected format for ID (%q), expected POLICY_NAME,POLICY_PATH,PERMISSION_SET_ARN,INSTANCE_ARN", id)
+	}
+
+	return idParts[0], idParts[1], idParts[2], idParts[3], nil
+}
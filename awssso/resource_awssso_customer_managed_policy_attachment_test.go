@@ -0,0 +1,80 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestResourceAwsSsoCustomerManagedPolicyAttachment_defaultPath(t *testing.T) {
+	provisioningStatus := map[string]interface{}{
+		"PermissionSetProvisioningStatus": map[string]interface{}{
+			"RequestId": "11111111-2222-3333-4444-555555555555",
+			"Status":    "SUCCEEDED",
+		},
+	}
+
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"AttachCustomerManagedPolicyReferenceToPermissionSet": {{}},
+		"ListCustomerManagedPolicyReferencesInPermissionSet": {{Body: map[string]interface{}{
+			"CustomerManagedPolicyReferences": []interface{}{
+				map[string]interface{}{"Name": "test", "Path": "/"},
+			},
+		}}},
+		"ProvisionPermissionSet":                  {{Body: provisioningStatus}},
+		"DescribePermissionSetProvisioningStatus": {{Body: provisioningStatus}},
+	})
+
+	state := testResourceApply(t, resourceAwsSsoCustomerManagedPolicyAttachment(), nil, map[string]interface{}{
+		"customer_managed_policy_reference": []interface{}{
+			map[string]interface{}{"name": "test"},
+		},
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	}, client)
+
+	if got, expected := state.ID, "test,/,arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := state.Attributes["customer_managed_policy_reference.0.path"], "/"; got != expected {
+		t.Errorf("got path %s, expected %s", got, expected)
+	}
+
+	attaches := api.Requests("AttachCustomerManagedPolicyReferenceToPermissionSet")
+
+	if got, expected := len(attaches), 1; got != expected {
+		t.Fatalf("got %d attach calls, expected %d", got, expected)
+	}
+
+	reference, _ := attaches[0].Body["CustomerManagedPolicyReference"].(map[string]interface{})
+
+	if got, expected := reference["Path"], "/"; got != expected {
+		t.Errorf("got requested path %v, expected %s", got, expected)
+	}
+}
+
+func TestResourceAwsSsoCustomerManagedPolicyAttachment_removedOutOfBand(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"ListCustomerManagedPolicyReferencesInPermissionSet": {{Body: map[string]interface{}{
+			"CustomerManagedPolicyReferences": []interface{}{
+				map[string]interface{}{"Name": "other", "Path": "/"},
+			},
+		}}},
+	})
+
+	r := resourceAwsSsoCustomerManagedPolicyAttachment()
+	d := r.Data(&terraform.InstanceState{
+		ID: "test,/,arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111",
+	})
+
+	if diags := resourceAwsSsoCustomerManagedPolicyAttachmentRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading resource: %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected detached reference to be removed from state, got ID: %s", d.Id())
+	}
+}
@@ -0,0 +1,248 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tfidentitystore "github.com/takescoop/terraform-provider-awssso/awssso/internal/service/identitystore"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/identitystore/finder"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/identitystore/waiter"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/tfresource"
+)
+
+func resourceAwsSsoGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoGroupCreate,
+		ReadContext:   resourceAwsSsoGroupRead,
+		UpdateContext: resourceAwsSsoGroupUpdate,
+		DeleteContext: resourceAwsSsoGroupDelete,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 1024),
+			},
+			"display_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 1024),
+			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"identity_store_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 36),
+			},
+		},
+	}
+}
+
+func resourceAwsSsoGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	identityStoreID := d.Get("identity_store_id").(string)
+	displayName := d.Get("display_name").(string)
+
+	input := &identitystore.CreateGroupInput{
+		DisplayName:     aws.String(displayName),
+		IdentityStoreId: aws.String(identityStoreID),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+
+	output, err := conn.CreateGroupWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Identity Store Group (%s): %w", displayName, cleanAwsRequestError(err)))
+	}
+
+	if output == nil || output.GroupId == nil {
+		return diag.Errorf("error creating Identity Store Group (%s): empty output", displayName)
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s", aws.StringValue(output.GroupId), identityStoreID))
+
+	return resourceAwsSsoGroupRead(ctx, d, meta)
+}
+
+func resourceAwsSsoGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	groupID, identityStoreID, err := parseSsoGroupID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &identitystore.DescribeGroupInput{
+		GroupId:         aws.String(groupID),
+		IdentityStoreId: aws.String(identityStoreID),
+	}
+
+	var output *identitystore.DescribeGroupOutput
+
+	// A group may not be found immediately after it is created.
+	err = resource.RetryContext(ctx, waiter.GroupPropagationTimeout, func() *resource.RetryError {
+		var err error
+
+		output, err = conn.DescribeGroupWithContext(ctx, input)
+
+		if d.IsNewResource() && tfawserr.ErrCodeEquals(err, identitystore.ErrCodeResourceNotFoundException) {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if tfresource.TimedOut(err) {
+		output, err = conn.DescribeGroupWithContext(ctx, input)
+	}
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, identitystore.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] Identity Store Group (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Identity Store Group (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	if output == nil {
+		return diag.Errorf("error reading Identity Store Group (%s): empty output", d.Id())
+	}
+
+	d.Set("description", output.Description)
+	d.Set("display_name", output.DisplayName)
+	d.Set("group_id", output.GroupId)
+	d.Set("identity_store_id", output.IdentityStoreId)
+
+	return nil
+}
+
+func resourceAwsSsoGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	groupID, identityStoreID, err := parseSsoGroupID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &tfidentitystore.UpdateGroupInput{
+		GroupId:         aws.String(groupID),
+		IdentityStoreId: aws.String(identityStoreID),
+	}
+
+	if d.HasChange("description") {
+		operation := &tfidentitystore.AttributeOperation{
+			AttributePath: aws.String("description"),
+		}
+
+		if v, ok := d.GetOk("description"); ok {
+			operation.AttributeValue = aws.String(v.(string))
+		}
+
+		input.Operations = append(input.Operations, operation)
+	}
+
+	if d.HasChange("display_name") {
+		input.Operations = append(input.Operations, &tfidentitystore.AttributeOperation{
+			AttributePath:  aws.String("displayName"),
+			AttributeValue: aws.String(d.Get("display_name").(string)),
+		})
+	}
+
+	if len(input.Operations) > 0 {
+		if _, err := tfidentitystore.UpdateGroup(ctx, conn, input); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating Identity Store Group (%s): %w", d.Id(), cleanAwsRequestError(err)))
+		}
+	}
+
+	return resourceAwsSsoGroupRead(ctx, d, meta)
+}
+
+func resourceAwsSsoGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	groupID, identityStoreID, err := parseSsoGroupID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("force_destroy").(bool) {
+		memberships, err := finder.GroupMemberships(ctx, conn, identityStoreID, groupID)
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error listing Identity Store Group (%s) memberships: %w", d.Id(), cleanAwsRequestError(err)))
+		}
+
+		for _, membership := range memberships {
+			membershipID := aws.StringValue(membership.MembershipId)
+
+			_, err := conn.DeleteGroupMembershipWithContext(ctx, &identitystore.DeleteGroupMembershipInput{
+				IdentityStoreId: aws.String(identityStoreID),
+				MembershipId:    aws.String(membershipID),
+			})
+
+			if tfawserr.ErrCodeEquals(err, identitystore.ErrCodeResourceNotFoundException) {
+				continue
+			}
+
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error deleting Identity Store Group (%s) membership (%s): %w", d.Id(), membershipID, cleanAwsRequestError(err)))
+			}
+		}
+	}
+
+	_, err = conn.DeleteGroupWithContext(ctx, &identitystore.DeleteGroupInput{
+		GroupId:         aws.String(groupID),
+		IdentityStoreId: aws.String(identityStoreID),
+	})
+
+	if tfawserr.ErrCodeEquals(err, identitystore.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Identity Store Group (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	return nil
+}
+
+func parseSsoGroupID(id string) (string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format for ID (%q), expected GROUP_ID,IDENTITY_STORE_ID", id)
+	}
+
+	return idParts[0], idParts[1], nil
+}
@@ -0,0 +1,146 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAwsSsoGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoGroupMembershipCreate,
+		ReadContext:   resourceAwsSsoGroupMembershipRead,
+		DeleteContext: resourceAwsSsoGroupMembershipDelete,
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 47),
+			},
+			"identity_store_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 36),
+			},
+			"member_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 47),
+			},
+			"membership_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsSsoGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	groupID := d.Get("group_id").(string)
+	identityStoreID := d.Get("identity_store_id").(string)
+	memberID := d.Get("member_id").(string)
+
+	input := &identitystore.CreateGroupMembershipInput{
+		GroupId:         aws.String(groupID),
+		IdentityStoreId: aws.String(identityStoreID),
+		MemberId: &identitystore.MemberId{
+			UserId: aws.String(memberID),
+		},
+	}
+
+	output, err := conn.CreateGroupMembershipWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Identity Store Group (%s) Membership for member (%s): %w", groupID, memberID, cleanAwsRequestError(err)))
+	}
+
+	if output == nil || output.MembershipId == nil {
+		return diag.Errorf("error creating Identity Store Group (%s) Membership for member (%s): empty output", groupID, memberID)
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s,%s", groupID, memberID, identityStoreID))
+
+	return resourceAwsSsoGroupMembershipRead(ctx, d, meta)
+}
+
+func resourceAwsSsoGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	groupID, memberID, identityStoreID, err := parseSsoGroupMembershipID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	output, err := conn.GetGroupMembershipIdWithContext(ctx, &identitystore.GetGroupMembershipIdInput{
+		GroupId:         aws.String(groupID),
+		IdentityStoreId: aws.String(identityStoreID),
+		MemberId: &identitystore.MemberId{
+			UserId: aws.String(memberID),
+		},
+	})
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, identitystore.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] Identity Store Group Membership (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Identity Store Group Membership (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	if output == nil || output.MembershipId == nil {
+		return diag.Errorf("error reading Identity Store Group Membership (%s): empty output", d.Id())
+	}
+
+	d.Set("group_id", groupID)
+	d.Set("identity_store_id", identityStoreID)
+	d.Set("member_id", memberID)
+	d.Set("membership_id", output.MembershipId)
+
+	return nil
+}
+
+func resourceAwsSsoGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	_, err := conn.DeleteGroupMembershipWithContext(ctx, &identitystore.DeleteGroupMembershipInput{
+		IdentityStoreId: aws.String(d.Get("identity_store_id").(string)),
+		MembershipId:    aws.String(d.Get("membership_id").(string)),
+	})
+
+	if tfawserr.ErrCodeEquals(err, identitystore.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Identity Store Group Membership (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	return nil
+}
+
+func parseSsoGroupMembershipID(id string) (string, string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format for ID (%q), expected GROUP_ID,MEMBER_ID,IDENTITY_STORE_ID", id)
+	}
+
+	return idParts[0], idParts[1], idParts[2], nil
+}
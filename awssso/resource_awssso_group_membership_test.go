@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestResourceAwsSsoGroupMembership_lifecycle(t *testing.T) {
+	membership := map[string]interface{}{
+		"IdentityStoreId": "d-1111111111",
+		"MembershipId":    "33333333-3333-3333-3333-333333333333",
+	}
+
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreateGroupMembership": {{Body: membership}},
+		"GetGroupMembershipId":  {{Body: membership}},
+		"DeleteGroupMembership": {{}},
+	})
+
+	r := resourceAwsSsoGroupMembership()
+	state := testResourceApply(t, r, nil, map[string]interface{}{
+		"group_id":          "11111111-1111-1111-1111-111111111111",
+		"identity_store_id": "d-1111111111",
+		"member_id":         "22222222-2222-2222-2222-222222222222",
+	}, client)
+
+	if got, expected := state.ID, "11111111-1111-1111-1111-111111111111,22222222-2222-2222-2222-222222222222,d-1111111111"; got != expected {
+		t.Fatalf("got ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := state.Attributes["membership_id"], "33333333-3333-3333-3333-333333333333"; got != expected {
+		t.Errorf("got membership_id %s, expected %s", got, expected)
+	}
+
+	creates := api.Requests("CreateGroupMembership")
+
+	if got, expected := len(creates), 1; got != expected {
+		t.Fatalf("got %d CreateGroupMembership calls, expected %d", got, expected)
+	}
+
+	expectedMemberID := map[string]interface{}{"UserId": "22222222-2222-2222-2222-222222222222"}
+
+	if got := creates[0].Body["MemberId"]; !reflect.DeepEqual(got, expectedMemberID) {
+		t.Errorf("got MemberId %v, expected %v", got, expectedMemberID)
+	}
+
+	testResourceDestroy(t, r, state, client)
+
+	deletes := api.Requests("DeleteGroupMembership")
+
+	if got, expected := len(deletes), 1; got != expected {
+		t.Fatalf("got %d DeleteGroupMembership calls, expected %d", got, expected)
+	}
+
+	if got, expected := deletes[0].Body["MembershipId"], "33333333-3333-3333-3333-333333333333"; got != expected {
+		t.Errorf("got MembershipId %v, expected %s", got, expected)
+	}
+}
+
+func TestResourceAwsSsoGroupMembership_drift(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"GetGroupMembershipId": {{ErrorCode: identitystore.ErrCodeResourceNotFoundException}},
+	})
+
+	r := resourceAwsSsoGroupMembership()
+	d := r.Data(&terraform.InstanceState{
+		ID: "11111111-1111-1111-1111-111111111111,22222222-2222-2222-2222-222222222222,d-1111111111",
+	})
+
+	if diags := resourceAwsSsoGroupMembershipRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading resource: %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected deleted membership to be removed from state, got ID: %s", d.Id())
+	}
+}
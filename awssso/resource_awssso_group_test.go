@@ -0,0 +1,220 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func testGroupResponse(displayName, description string) map[string]interface{} {
+	return map[string]interface{}{
+		"Description":     description,
+		"DisplayName":     displayName,
+		"GroupId":         "11111111-1111-1111-1111-111111111111",
+		"IdentityStoreId": "d-1111111111",
+	}
+}
+
+func TestResourceAwsSsoGroup_lifecycle(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreateGroup": {{Body: map[string]interface{}{
+			"GroupId":         "11111111-1111-1111-1111-111111111111",
+			"IdentityStoreId": "d-1111111111",
+		}}},
+		"DescribeGroup": {
+			{Body: testGroupResponse("Engineering", "create")},
+			{Body: testGroupResponse("Engineering", "update")},
+		},
+		"UpdateGroup": {{}},
+		"DeleteGroup": {{}},
+	})
+
+	r := resourceAwsSsoGroup()
+	raw := map[string]interface{}{
+		"description":       "create",
+		"display_name":      "Engineering",
+		"identity_store_id": "d-1111111111",
+	}
+
+	state := testResourceApply(t, r, nil, raw, client)
+
+	if got, expected := state.ID, "11111111-1111-1111-1111-111111111111,d-1111111111"; got != expected {
+		t.Fatalf("got ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := state.Attributes["group_id"], "11111111-1111-1111-1111-111111111111"; got != expected {
+		t.Errorf("got group_id %s, expected %s", got, expected)
+	}
+
+	raw["description"] = "update"
+	state = testResourceApply(t, r, state, raw, client)
+
+	if got, expected := state.Attributes["description"], "update"; got != expected {
+		t.Errorf("got description %s, expected %s", got, expected)
+	}
+
+	updates := api.Requests("UpdateGroup")
+
+	if got, expected := len(updates), 1; got != expected {
+		t.Fatalf("got %d UpdateGroup calls, expected %d", got, expected)
+	}
+
+	expectedOperations := []interface{}{
+		map[string]interface{}{"AttributePath": "description", "AttributeValue": "update"},
+	}
+
+	if got := updates[0].Body["Operations"]; !reflect.DeepEqual(got, expectedOperations) {
+		t.Errorf("got operations %v, expected %v", got, expectedOperations)
+	}
+
+	testResourceDestroy(t, r, state, client)
+
+	if got, expected := len(api.Requests("DeleteGroup")), 1; got != expected {
+		t.Errorf("got %d DeleteGroup calls, expected %d", got, expected)
+	}
+}
+
+func TestResourceAwsSsoGroup_createEventualConsistency(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreateGroup": {{Body: map[string]interface{}{
+			"GroupId":         "11111111-1111-1111-1111-111111111111",
+			"IdentityStoreId": "d-1111111111",
+		}}},
+		"DescribeGroup": {
+			{ErrorCode: identitystore.ErrCodeResourceNotFoundException},
+			{Body: testGroupResponse("Engineering", "")},
+		},
+	})
+
+	state := testResourceApply(t, resourceAwsSsoGroup(), nil, map[string]interface{}{
+		"display_name":      "Engineering",
+		"identity_store_id": "d-1111111111",
+	}, client)
+
+	if got, expected := state.ID, "11111111-1111-1111-1111-111111111111,d-1111111111"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := len(api.Requests("DescribeGroup")), 2; got != expected {
+		t.Errorf("got %d DescribeGroup calls, expected %d", got, expected)
+	}
+}
+
+func TestResourceAwsSsoGroup_createError(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"CreateGroup": {{ErrorCode: identitystore.ErrCodeAccessDeniedException}},
+	})
+
+	r := resourceAwsSsoGroup()
+
+	diff, err := testResourceDiff(r, nil, map[string]interface{}{
+		"display_name":      "Engineering",
+		"identity_store_id": "d-1111111111",
+	}, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	_, diags := r.Apply(context.Background(), nil, diff, client)
+
+	if !diags.HasError() {
+		t.Fatal("expected an error creating the group")
+	}
+
+	expected := "error creating Identity Store Group (Engineering): AccessDeniedException: mocked AccessDeniedException"
+
+	if got := diags[0].Summary; got != expected {
+		t.Errorf("got error %q, expected %q", got, expected)
+	}
+}
+
+func TestResourceAwsSsoGroup_forceDestroy(t *testing.T) {
+	testCases := []struct {
+		TestName           string
+		ForceDestroy       bool
+		ExpectedOperations []string
+	}{
+		{
+			TestName:     "enabled",
+			ForceDestroy: true,
+			ExpectedOperations: []string{
+				"ListGroupMemberships",
+				"DeleteGroupMembership",
+				"DeleteGroupMembership",
+				"DeleteGroup",
+			},
+		},
+		{
+			TestName: "disabled",
+			ExpectedOperations: []string{
+				"DeleteGroup",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ListGroupMemberships": {{Body: map[string]interface{}{
+					"GroupMemberships": []interface{}{
+						map[string]interface{}{"MembershipId": "membership-1", "MemberId": map[string]interface{}{"UserId": "user-1"}},
+						map[string]interface{}{"MembershipId": "membership-2", "MemberId": map[string]interface{}{"UserId": "user-2"}},
+					},
+				}}},
+				"DeleteGroupMembership": {
+					{},
+					{ErrorCode: identitystore.ErrCodeResourceNotFoundException},
+				},
+				"DeleteGroup": {{}},
+			})
+
+			r := resourceAwsSsoGroup()
+			d := r.Data(&terraform.InstanceState{
+				ID: "11111111-1111-1111-1111-111111111111,d-1111111111",
+			})
+			d.Set("force_destroy", testCase.ForceDestroy)
+
+			if diags := resourceAwsSsoGroupDelete(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("error deleting resource: %v", diags)
+			}
+
+			if got := api.Operations(); !reflect.DeepEqual(got, testCase.ExpectedOperations) {
+				t.Errorf("got operations %v, expected %v", got, testCase.ExpectedOperations)
+			}
+
+			var membershipIDs []interface{}
+
+			for _, request := range api.Requests("DeleteGroupMembership") {
+				membershipIDs = append(membershipIDs, request.Body["MembershipId"])
+			}
+
+			if testCase.ForceDestroy && !reflect.DeepEqual(membershipIDs, []interface{}{"membership-1", "membership-2"}) {
+				t.Errorf("got deleted memberships %v, expected membership-1 and membership-2", membershipIDs)
+			}
+		})
+	}
+}
+
+func TestResourceAwsSsoGroup_removedOutOfBand(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"DescribeGroup": {{ErrorCode: identitystore.ErrCodeResourceNotFoundException}},
+	})
+
+	r := resourceAwsSsoGroup()
+	d := r.Data(&terraform.InstanceState{
+		ID: "11111111-1111-1111-1111-111111111111,d-1111111111",
+	})
+
+	if diags := resourceAwsSsoGroupRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading resource: %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected deleted group to be removed from state, got ID: %s", d.Id())
+	}
+}
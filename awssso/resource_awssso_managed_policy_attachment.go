@@ -0,0 +1,162 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+)
+
+func resourceAwsSsoManagedPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoManagedPolicyAttachmentCreate,
+		ReadContext:   resourceAwsSsoManagedPolicyAttachmentRead,
+		DeleteContext: resourceAwsSsoManagedPolicyAttachmentDelete,
+
+		Schema: map[string]*schema.Schema{
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"managed_policy_arn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateArn,
+				DiffSuppressFunc: suppressEquivalentManagedPolicyArnDiffs,
+			},
+			"managed_policy_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func resourceAwsSsoManagedPolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn, err := ssoInstanceArn(ctx, d, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	managedPolicyArn := managedPolicyArnInPartition(d.Get("managed_policy_arn").(string), meta.(*AWSClient).Partition())
+	permissionSetArn := d.Get("permission_set_arn").(string)
+
+	input := &ssoadmin.AttachManagedPolicyToPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		ManagedPolicyArn: aws.String(managedPolicyArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}
+
+	_, err = conn.AttachManagedPolicyToPermissionSetWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error attaching Managed Policy (%s) to SSO Permission Set (%s): %w", managedPolicyArn, permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s,%s", managedPolicyArn, permissionSetArn, instanceArn))
+
+	diags := provisionPermissionSet(ctx, meta, instanceArn, permissionSetArn)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceAwsSsoManagedPolicyAttachmentRead(ctx, d, meta)...)
+}
+
+func resourceAwsSsoManagedPolicyAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	managedPolicyArn, permissionSetArn, instanceArn, err := parseSsoManagedPolicyAttachmentID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy, err := finder.ManagedPolicy(ctx, conn, managedPolicyArn, permissionSetArn, instanceArn)
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] Managed Policy (%s) for SSO Permission Set (%s) not found, removing from state", managedPolicyArn, permissionSetArn)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Managed Policy (%s) for SSO Permission Set (%s): %w", managedPolicyArn, permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	if policy == nil {
+		if d.IsNewResource() {
+			return diag.Errorf("error reading Managed Policy (%s) for SSO Permission Set (%s): not found", managedPolicyArn, permissionSetArn)
+		}
+
+		log.Printf("[WARN] Managed Policy (%s) for SSO Permission Set (%s) not found, removing from state", managedPolicyArn, permissionSetArn)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("instance_arn", instanceArn)
+	d.Set("managed_policy_arn", policy.Arn)
+	d.Set("managed_policy_name", policy.Name)
+	d.Set("permission_set_arn", permissionSetArn)
+
+	return nil
+}
+
+func resourceAwsSsoManagedPolicyAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	managedPolicyArn, permissionSetArn, instanceArn, err := parseSsoManagedPolicyAttachmentID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &ssoadmin.DetachManagedPolicyFromPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		ManagedPolicyArn: aws.String(managedPolicyArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}
+
+	_, err = conn.DetachManagedPolicyFromPermissionSetWithContext(ctx, input)
+
+	if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error detaching Managed Policy (%s) from SSO Permission Set (%s): %w", managedPolicyArn, permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	return provisionPermissionSet(ctx, meta, instanceArn, permissionSetArn)
+}
+
+func parseSsoManagedPolicyAttachmentID(id string) (string, string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format for ID (%q), expected MANAGED_POLICY_ARN,PERMISSION_SET_ARN,INSTANCE_ARN", id)
+	}
+
+	return idParts[0], idParts[1], idParts[2], nil
+}
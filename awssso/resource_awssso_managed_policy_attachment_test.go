@@ -0,0 +1,224 @@
+package aws
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestResourceAwsSsoManagedPolicyAttachment_attachDetach(t *testing.T) {
+	provisioningStatus := map[string]interface{}{
+		"PermissionSetProvisioningStatus": map[string]interface{}{
+			"RequestId": "11111111-2222-3333-4444-555555555555",
+			"Status":    "SUCCEEDED",
+		},
+	}
+
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"AttachManagedPolicyToPermissionSet":   {{}},
+		"DetachManagedPolicyFromPermissionSet": {{}},
+		"ListManagedPoliciesInPermissionSet": {
+			{Body: map[string]interface{}{
+				"AttachedManagedPolicies": []interface{}{
+					map[string]interface{}{"Arn": "arn:aws:iam::aws:policy/AdministratorAccess", "Name": "AdministratorAccess"},
+				},
+				"NextToken": "page-2",
+			}},
+			{Body: map[string]interface{}{
+				"AttachedManagedPolicies": []interface{}{
+					map[string]interface{}{"Arn": "arn:aws:iam::aws:policy/ReadOnlyAccess", "Name": "ReadOnlyAccess"},
+				},
+			}},
+		},
+		"ProvisionPermissionSet":                  {{Body: provisioningStatus}},
+		"DescribePermissionSetProvisioningStatus": {{Body: provisioningStatus}},
+	})
+
+	r := resourceAwsSsoManagedPolicyAttachment()
+
+	state := testResourceApply(t, r, nil, map[string]interface{}{
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"managed_policy_arn": "arn:aws:iam::aws:policy/ReadOnlyAccess",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	}, client)
+
+	if got, expected := state.Attributes["managed_policy_name"], "ReadOnlyAccess"; got != expected {
+		t.Errorf("got managed_policy_name %s, expected %s", got, expected)
+	}
+
+	if got, expected := len(api.Requests("ListManagedPoliciesInPermissionSet")), 2; got != expected {
+		t.Errorf("got %d ListManagedPoliciesInPermissionSet calls, expected %d", got, expected)
+	}
+
+	testResourceDestroy(t, r, state, client)
+
+	if got, expected := len(api.Requests("DetachManagedPolicyFromPermissionSet")), 1; got != expected {
+		t.Errorf("got %d DetachManagedPolicyFromPermissionSet calls, expected %d", got, expected)
+	}
+
+	provisions := api.Requests("ProvisionPermissionSet")
+
+	if got, expected := len(provisions), 2; got != expected {
+		t.Fatalf("got %d ProvisionPermissionSet calls, expected %d", got, expected)
+	}
+
+	if got, expected := provisions[0].Body["TargetType"], "ALL_PROVISIONED_ACCOUNTS"; got != expected {
+		t.Errorf("got provisioning target type %v, expected %s", got, expected)
+	}
+}
+
+func TestResourceAwsSsoManagedPolicyAttachment_inferInstanceArn(t *testing.T) {
+	provisioningStatus := map[string]interface{}{
+		"PermissionSetProvisioningStatus": map[string]interface{}{
+			"RequestId": "11111111-2222-3333-4444-555555555555",
+			"Status":    "SUCCEEDED",
+		},
+	}
+
+	testCases := []struct {
+		TestName            string
+		Instances           []interface{}
+		ExpectedInstanceArn string
+		ExpectedError       *regexp.Regexp
+	}{
+		{
+			TestName: "single instance",
+			Instances: []interface{}{
+				map[string]interface{}{"InstanceArn": "arn:aws:sso:::instance/ssoins-1111111111111111", "IdentityStoreId": "d-1111111111"},
+			},
+			ExpectedInstanceArn: "arn:aws:sso:::instance/ssoins-1111111111111111",
+		},
+		{
+			TestName: "multiple instances",
+			Instances: []interface{}{
+				map[string]interface{}{"InstanceArn": "arn:aws:sso:::instance/ssoins-1111111111111111", "IdentityStoreId": "d-1111111111"},
+				map[string]interface{}{"InstanceArn": "arn:aws:sso:::instance/ssoins-2222222222222222", "IdentityStoreId": "d-2222222222"},
+			},
+			ExpectedError: regexp.MustCompile(`error discovering instance_arn, configure it explicitly: found too many SSO instances \(2\)`),
+		},
+		{
+			TestName:      "no instances",
+			Instances:     []interface{}{},
+			ExpectedError: regexp.MustCompile(`error discovering instance_arn, configure it explicitly: couldn't find any SSO instances`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"AttachManagedPolicyToPermissionSet": {{}},
+				"ListInstances":                      {{Body: map[string]interface{}{"Instances": testCase.Instances}}},
+				"ListManagedPoliciesInPermissionSet": {{Body: map[string]interface{}{
+					"AttachedManagedPolicies": []interface{}{
+						map[string]interface{}{"Arn": "arn:aws:iam::aws:policy/ReadOnlyAccess", "Name": "ReadOnlyAccess"},
+					},
+				}}},
+				"ProvisionPermissionSet":                  {{Body: provisioningStatus}},
+				"DescribePermissionSetProvisioningStatus": {{Body: provisioningStatus}},
+			})
+
+			r := resourceAwsSsoManagedPolicyAttachment()
+
+			diff, err := testResourceDiff(r, nil, map[string]interface{}{
+				"managed_policy_arn": "arn:aws:iam::aws:policy/ReadOnlyAccess",
+				"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			}, client)
+
+			if err != nil {
+				t.Fatalf("error planning resource: %s", err)
+			}
+
+			state, diags := r.Apply(context.Background(), nil, diff, client)
+
+			if testCase.ExpectedError != nil {
+				if !diags.HasError() {
+					t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+				}
+
+				if !testCase.ExpectedError.MatchString(diags[0].Summary) {
+					t.Errorf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Summary)
+				}
+
+				if got := len(api.Requests("AttachManagedPolicyToPermissionSet")); got != 0 {
+					t.Errorf("got %d AttachManagedPolicyToPermissionSet calls, expected none", got)
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("error applying resource: %v", diags)
+			}
+
+			if got := state.Attributes["instance_arn"]; got != testCase.ExpectedInstanceArn {
+				t.Errorf("got instance_arn %s, expected %s", got, testCase.ExpectedInstanceArn)
+			}
+
+			attaches := api.Requests("AttachManagedPolicyToPermissionSet")
+
+			if got, expected := len(attaches), 1; got != expected {
+				t.Fatalf("got %d AttachManagedPolicyToPermissionSet calls, expected %d", got, expected)
+			}
+
+			if got := attaches[0].Body["InstanceArn"]; got != testCase.ExpectedInstanceArn {
+				t.Errorf("got InstanceArn %v, expected %s", got, testCase.ExpectedInstanceArn)
+			}
+		})
+	}
+}
+
+func TestResourceAwsSsoManagedPolicyAttachment_partition(t *testing.T) {
+	provisioningStatus := map[string]interface{}{
+		"PermissionSetProvisioningStatus": map[string]interface{}{
+			"RequestId": "11111111-2222-3333-4444-555555555555",
+			"Status":    "SUCCEEDED",
+		},
+	}
+
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"AttachManagedPolicyToPermissionSet": {{}},
+		"ListManagedPoliciesInPermissionSet": {{Body: map[string]interface{}{
+			"AttachedManagedPolicies": []interface{}{
+				map[string]interface{}{"Arn": "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess", "Name": "ReadOnlyAccess"},
+			},
+		}}},
+		"ProvisionPermissionSet":                  {{Body: provisioningStatus}},
+		"DescribePermissionSetProvisioningStatus": {{Body: provisioningStatus}},
+	})
+	client.partition = "aws-us-gov"
+
+	r := resourceAwsSsoManagedPolicyAttachment()
+	config := map[string]interface{}{
+		"instance_arn":       "arn:aws-us-gov:sso:::instance/ssoins-1111111111111111",
+		"managed_policy_arn": "arn:aws:iam::aws:policy/ReadOnlyAccess",
+		"permission_set_arn": "arn:aws-us-gov:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	}
+
+	state := testResourceApply(t, r, nil, config, client)
+
+	attaches := api.Requests("AttachManagedPolicyToPermissionSet")
+
+	if got, expected := len(attaches), 1; got != expected {
+		t.Fatalf("got %d AttachManagedPolicyToPermissionSet calls, expected %d", got, expected)
+	}
+
+	if got, expected := attaches[0].Body["ManagedPolicyArn"], "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess"; got != expected {
+		t.Errorf("got ManagedPolicyArn %v, expected %s", got, expected)
+	}
+
+	if got, expected := state.Attributes["managed_policy_arn"], "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess"; got != expected {
+		t.Errorf("got managed_policy_arn %s, expected %s", got, expected)
+	}
+
+	diff, err := testResourceDiff(r, state, config, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff for the commercial managed policy ARN, got: %#v", diff.Attributes)
+	}
+}
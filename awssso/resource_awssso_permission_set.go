@@ -0,0 +1,572 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/corehandlers"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/waiter"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/tfresource"
+)
+
+func resourceAwsSsoPermissionSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoPermissionSetCreate,
+		ReadContext:   resourceAwsSsoPermissionSetRead,
+		UpdateContext: resourceAwsSsoPermissionSetUpdate,
+		DeleteContext: resourceAwsSsoPermissionSetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAwsSsoPermissionSetImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 700),
+					validation.StringMatch(regexp.MustCompile(`^[\p{L}\p{M}\p{Z}\p{S}\p{N}\p{P}]*$`), "must match [\\p{L}\\p{M}\\p{Z}\\p{S}\\p{N}\\p{P}]"),
+				),
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 32),
+					validation.StringMatch(regexp.MustCompile(`^[\w+=,.@-]+$`), "must match [\\w+=,.@-]"),
+				),
+			},
+			"failure_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"provisioning_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"relay_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, 240),
+					validateAbsoluteURL,
+				),
+			},
+			"session_duration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressEquivalentIso8601Durations,
+				ValidateFunc:     validateSsoSessionDuration,
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsSchemaComputed(),
+		},
+
+		CustomizeDiff: SetTagsDiff,
+	}
+}
+
+// ssoDefaultSessionDuration is the session duration of new permission sets
+// when neither the resource nor the provider configures one.
+const ssoDefaultSessionDuration = "PT1H"
+
+func resourceAwsSsoPermissionSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+	tags := mergeTags(meta.(*AWSClient), keyvaluetags.New(d.Get("tags").(map[string]interface{})))
+
+	instanceArn, err := ssoInstanceArn(ctx, d, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+
+	input := &ssoadmin.CreatePermissionSetInput{
+		InstanceArn: aws.String(instanceArn),
+		Name:        aws.String(name),
+	}
+
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("relay_state"); ok {
+		input.RelayState = aws.String(v.(string))
+	}
+
+	// An omitted session_duration falls back to the provider
+	// default_session_duration. Existing permission sets keep their current
+	// duration when it is later omitted.
+	if v, ok := d.GetOk("session_duration"); ok {
+		input.SessionDuration = aws.String(v.(string))
+	} else if v := meta.(*AWSClient).DefaultSessionDuration; v != "" {
+		input.SessionDuration = aws.String(v)
+	} else {
+		input.SessionDuration = aws.String(ssoDefaultSessionDuration)
+	}
+
+	// Tagging on create avoids leaving an untagged permission set behind when
+	// a separate TagResource call fails.
+	if len(tags) > 0 {
+		input.Tags = tags.IgnoreAws().SsoadminTags()
+	}
+
+	output, err := conn.CreatePermissionSetWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating SSO Permission Set (%s): %w", name, cleanAwsRequestError(err)))
+	}
+
+	if output == nil || output.PermissionSet == nil {
+		return diag.Errorf("error creating SSO Permission Set (%s): empty output", name)
+	}
+
+	d.SetId(aws.StringValue(output.PermissionSet.PermissionSetArn))
+
+	// A new permission set is not provisioned to any accounts, so there is no
+	// provisioning status until it is first updated.
+	d.Set("provisioning_status", "")
+	d.Set("failure_reason", "")
+
+	return resourceAwsSsoPermissionSetRead(ctx, d, meta)
+}
+
+func resourceAwsSsoPermissionSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
+
+	instanceArn := d.Get("instance_arn").(string)
+
+	input := &ssoadmin.DescribePermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(d.Id()),
+	}
+
+	var output *ssoadmin.DescribePermissionSetOutput
+
+	// A permission set may not be found immediately after it is created.
+	err := resource.RetryContext(ctx, waiter.PermissionSetPropagationTimeout, func() *resource.RetryError {
+		var err error
+
+		output, err = conn.DescribePermissionSetWithContext(ctx, input)
+
+		if d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if tfresource.TimedOut(err) {
+		output, err = conn.DescribePermissionSetWithContext(ctx, input)
+	}
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] SSO Permission Set (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading SSO Permission Set (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	if output == nil || output.PermissionSet == nil {
+		return diag.Errorf("error reading SSO Permission Set (%s): empty output", d.Id())
+	}
+
+	permissionSet := output.PermissionSet
+
+	d.Set("arn", permissionSet.PermissionSetArn)
+	if permissionSet.CreatedDate != nil {
+		d.Set("created_date", permissionSet.CreatedDate.Format(time.RFC3339))
+	}
+	d.Set("description", permissionSet.Description)
+	d.Set("instance_arn", instanceArn)
+	d.Set("name", permissionSet.Name)
+	d.Set("relay_state", permissionSet.RelayState)
+	d.Set("session_duration", permissionSet.SessionDuration)
+
+	tags, err := keyvaluetags.SsoadminListTagsWithContext(ctx, conn, d.Id(), instanceArn)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing tags for SSO Permission Set (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	tags = ignoreTags(meta.(*AWSClient), tags.IgnoreAws())
+
+	//lintignore:AWSR002
+	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting tags: %w", err))
+	}
+
+	if err := setTagsComputed(d, "tags_all", tags, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAwsSsoPermissionSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	var diags diag.Diagnostics
+
+	instanceArn := d.Get("instance_arn").(string)
+
+	if d.HasChanges("description", "relay_state", "session_duration") {
+		input := &ssoadmin.UpdatePermissionSetInput{
+			InstanceArn:      aws.String(instanceArn),
+			PermissionSetArn: aws.String(d.Id()),
+		}
+
+		if d.HasChange("description") {
+			input.Description = aws.String(d.Get("description").(string))
+		}
+
+		// The SSO Admin API clears the existing RelayState when it is omitted
+		// from the request, so it is sent regardless of whether it changed.
+		if v, ok := d.GetOk("relay_state"); ok {
+			input.RelayState = aws.String(v.(string))
+		}
+
+		if d.HasChange("session_duration") {
+			input.SessionDuration = aws.String(d.Get("session_duration").(string))
+		}
+
+		var opts []request.Option
+
+		// Removing the description requires sending an empty value, which the
+		// SDK's client-side minimum length validation would otherwise reject.
+		if input.Description != nil && aws.StringValue(input.Description) == "" {
+			opts = append(opts, withoutParamValidation)
+		}
+
+		_, err := conn.UpdatePermissionSetWithContext(ctx, input, opts...)
+
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error updating SSO Permission Set (%s): %w", d.Id(), cleanAwsRequestError(err)))
+		}
+
+		diags = resourceAwsSsoPermissionSetProvision(ctx, d, meta)
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if d.HasChange("tags_all") {
+		o, n := d.GetChange("tags_all")
+
+		if err := keyvaluetags.SsoadminUpdateTagsWithContext(ctx, conn, d.Id(), instanceArn, o, n); err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error updating SSO Permission Set (%s) tags: %w", d.Id(), cleanAwsRequestError(err)))...)
+		}
+	}
+
+	return append(diags, resourceAwsSsoPermissionSetRead(ctx, d, meta)...)
+}
+
+// resourceAwsSsoPermissionSetProvision provisions the permission set after a
+// change to it, recording the status of a provisioning that did not succeed.
+func resourceAwsSsoPermissionSetProvision(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*AWSClient)
+
+	if client.skipAutoProvision {
+		return provisionPermissionSet(ctx, meta, d.Get("instance_arn").(string), d.Id())
+	}
+
+	status, err := client.ProvisionPermissionSet(ctx, d.Get("instance_arn").(string), d.Id())
+
+	if err != nil {
+		// The provisioning request itself may fail before a status is
+		// returned, in which case the error is the failure reason.
+		if status == nil {
+			status = &ssoadmin.PermissionSetProvisioningStatus{
+				Status:        aws.String(ssoadmin.StatusValuesFailed),
+				FailureReason: aws.String(err.Error()),
+			}
+		}
+
+		d.Set("provisioning_status", status.Status)
+		d.Set("failure_reason", status.FailureReason)
+
+		return diag.FromErr(err)
+	}
+
+	d.Set("provisioning_status", ssoadmin.StatusValuesSucceeded)
+	d.Set("failure_reason", "")
+
+	return nil
+}
+
+func resourceAwsSsoPermissionSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	_, err := conn.DeletePermissionSetWithContext(ctx, &ssoadmin.DeletePermissionSetInput{
+		InstanceArn:      aws.String(d.Get("instance_arn").(string)),
+		PermissionSetArn: aws.String(d.Id()),
+	})
+
+	if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting SSO Permission Set (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	return nil
+}
+
+// resourceAwsSsoPermissionSetImport imports a permission set using an ID of
+// the form INSTANCE_ARN,PERMISSION_SET_ARN, as the instance ARN cannot be
+// derived from the permission set ARN.
+func resourceAwsSsoPermissionSetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(d.Id(), ",")
+
+	if len(idParts) != 2 || !arn.IsARN(idParts[0]) || !arn.IsARN(idParts[1]) {
+		return nil, fmt.Errorf("unexpected format for ID (%q), expected INSTANCE_ARN,PERMISSION_SET_ARN", d.Id())
+	}
+
+	d.SetId(idParts[1])
+	d.Set("instance_arn", idParts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// provisionAndWait provisions a permission set to all accounts it is already
+// provisioned to and waits for the provisioning to complete, returning the
+// last provisioning status and the failure reason if it fails.
+func provisionAndWait(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, permissionSetArn string) (*ssoadmin.PermissionSetProvisioningStatus, error) {
+	input := &ssoadmin.ProvisionPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+		TargetType:       aws.String(ssoadmin.ProvisionTargetTypeAllProvisionedAccounts),
+	}
+
+	output, err := conn.ProvisionPermissionSetWithContext(ctx, input, retryOnRetryableError)
+
+	if err != nil {
+		return nil, fmt.Errorf("error provisioning SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err))
+	}
+
+	if output == nil || output.PermissionSetProvisioningStatus == nil {
+		return nil, fmt.Errorf("error provisioning SSO Permission Set (%s): empty output", permissionSetArn)
+	}
+
+	status, err := waiter.PermissionSetProvisioned(ctx, conn, instanceArn, aws.StringValue(output.PermissionSetProvisioningStatus.RequestId))
+
+	if err != nil {
+		return status, fmt.Errorf("error waiting for SSO Permission Set (%s) to provision: %w", permissionSetArn, cleanAwsRequestError(err))
+	}
+
+	return status, nil
+}
+
+// provisionPermissionSet provisions a permission set after a change to it, or
+// warns that it must be provisioned manually if automatic provisioning is
+// disabled.
+func provisionPermissionSet(ctx context.Context, meta interface{}, instanceArn, permissionSetArn string) diag.Diagnostics {
+	client := meta.(*AWSClient)
+
+	if client.skipAutoProvision {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("SSO Permission Set (%s) not provisioned", permissionSetArn),
+			Detail: "Automatic provisioning is disabled by the provider auto_provision argument. " +
+				"The change will not apply to the accounts the permission set is provisioned to until it is provisioned manually.",
+		}}
+	}
+
+	_, err := client.ProvisionPermissionSet(ctx, instanceArn, permissionSetArn)
+
+	return diag.FromErr(err)
+}
+
+// permissionSetProvisionDelay is how long a provision waits before starting,
+// so that other changes to the same permission set in the apply can join it.
+// Every automatic provision is delayed by it, even when no other change joins.
+const permissionSetProvisionDelay = 2 * time.Second
+
+// permissionSetProvisioner collapses provisions of the same permission set, so
+// that resources changing it in the same apply share a single provision
+// instead of each waiting on their own.
+type permissionSetProvisioner struct {
+	delay time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*permissionSetProvision
+	running map[string]chan struct{}
+}
+
+// permissionSetProvision is a provision that callers can join until it starts.
+type permissionSetProvision struct {
+	done   chan struct{}
+	status *ssoadmin.PermissionSetProvisioningStatus
+	err    error
+
+	// abandoned is set when the provision was stopped because the context of
+	// the caller that started it was cancelled.
+	abandoned bool
+}
+
+// provision provisions a permission set as provisionAndWait does. Callers
+// arriving before a pending provision of the same permission set starts join
+// it, and a provision only starts once the previous one for the permission set
+// has finished, so that it includes every change made before it started. When
+// the caller that started a provision is cancelled, the callers that joined it
+// provision again with their own contexts.
+func (p *permissionSetProvisioner) provision(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, permissionSetArn string) (*ssoadmin.PermissionSetProvisioningStatus, error) {
+	for {
+		p.mu.Lock()
+
+		if p.pending == nil {
+			p.pending = make(map[string]*permissionSetProvision)
+			p.running = make(map[string]chan struct{})
+		}
+
+		call, ok := p.pending[permissionSetArn]
+
+		if !ok {
+			break
+		}
+
+		p.mu.Unlock()
+
+		log.Printf("[DEBUG] Joining pending provision of SSO Permission Set (%s)", permissionSetArn)
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if !call.abandoned {
+			return call.status, call.err
+		}
+
+		log.Printf("[DEBUG] Pending provision of SSO Permission Set (%s) was cancelled, provisioning again", permissionSetArn)
+	}
+
+	call := &permissionSetProvision{done: make(chan struct{})}
+	p.pending[permissionSetArn] = call
+
+	running, ok := p.running[permissionSetArn]
+
+	if !ok {
+		running = make(chan struct{}, 1)
+		p.running[permissionSetArn] = running
+	}
+
+	p.mu.Unlock()
+
+	call.err = p.start(ctx, running, call, permissionSetArn)
+
+	if call.err == nil {
+		call.status, call.err = provisionAndWait(ctx, conn, instanceArn, permissionSetArn)
+		<-running
+	}
+
+	call.abandoned = ctx.Err() != nil
+	close(call.done)
+
+	return call.status, call.err
+}
+
+// start waits out the provisioner delay and any running provision of the
+// permission set, then stops other callers from joining the provision.
+func (p *permissionSetProvisioner) start(ctx context.Context, running chan struct{}, call *permissionSetProvision, permissionSetArn string) error {
+	var err error
+
+	select {
+	case <-time.After(p.delay):
+		select {
+		case running <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	p.mu.Lock()
+	delete(p.pending, permissionSetArn)
+	p.mu.Unlock()
+
+	return err
+}
+
+// retryOnRetryableError is a request option that retries errors classified by
+// tfresource.Retryable, such as the ConflictException returned while the permission set
+// is being provisioned concurrently, with the client's backoff up to its
+// maximum retries.
+func retryOnRetryableError(r *request.Request) {
+	r.Handlers.Retry.PushBack(func(r *request.Request) {
+		if tfresource.Retryable(r.Error) {
+			log.Printf("[DEBUG] Retrying %s after retryable error: %s", r.Operation.Name, r.Error)
+			r.Retryable = aws.Bool(true)
+		}
+	})
+}
+
+// withoutParamValidation is a request option that skips the SDK's client-side
+// parameter validation, leaving it to the API.
+func withoutParamValidation(r *request.Request) {
+	r.Handlers.Validate.Remove(corehandlers.ValidateParametersHandler)
+}
+
+// suppressEquivalentIso8601Durations suppresses differences between durations
+// of equal length, such as PT1H and PT60M.
+func suppressEquivalentIso8601Durations(k, old, new string, d *schema.ResourceData) bool {
+	oldDuration, err := parseIso8601Duration(old)
+
+	if err != nil {
+		return false
+	}
+
+	newDuration, err := parseIso8601Duration(new)
+
+	if err != nil {
+		return false
+	}
+
+	return oldDuration == newDuration
+}
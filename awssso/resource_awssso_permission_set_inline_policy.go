@@ -0,0 +1,173 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceAwsSsoPermissionSetInlinePolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoPermissionSetInlinePolicyPut,
+		ReadContext:   resourceAwsSsoPermissionSetInlinePolicyRead,
+		UpdateContext: resourceAwsSsoPermissionSetInlinePolicyPut,
+		DeleteContext: resourceAwsSsoPermissionSetInlinePolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"inline_policy": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateSsoInlinePolicy,
+				DiffSuppressFunc: suppressEquivalentPolicyDiffs,
+				StateFunc: func(v interface{}) string {
+					policy, _ := normalizePolicyJSON(v.(string))
+					return policy
+				},
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func resourceAwsSsoPermissionSetInlinePolicyPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn, err := ssoInstanceArn(ctx, d, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissionSetArn := d.Get("permission_set_arn").(string)
+
+	// The configured policy is sent in its normalized form, which is the form
+	// validated against the inline policy size limit.
+	policy, err := normalizePolicyJSON(d.Get("inline_policy").(string))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error normalizing Inline Policy for SSO Permission Set (%s): %w", permissionSetArn, err))
+	}
+
+	input := &ssoadmin.PutInlinePolicyToPermissionSetInput{
+		InlinePolicy:     aws.String(policy),
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}
+
+	_, err = conn.PutInlinePolicyToPermissionSetWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error putting Inline Policy for SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s", permissionSetArn, instanceArn))
+
+	diags := provisionPermissionSet(ctx, meta, instanceArn, permissionSetArn)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceAwsSsoPermissionSetInlinePolicyRead(ctx, d, meta)...)
+}
+
+func resourceAwsSsoPermissionSetInlinePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	permissionSetArn, instanceArn, err := parseSsoPermissionSetInlinePolicyID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	output, err := conn.GetInlinePolicyForPermissionSetWithContext(ctx, &ssoadmin.GetInlinePolicyForPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] Inline Policy for SSO Permission Set (%s) not found, removing from state", permissionSetArn)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Inline Policy for SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	if output == nil || aws.StringValue(output.InlinePolicy) == "" {
+		if d.IsNewResource() {
+			return diag.Errorf("error reading Inline Policy for SSO Permission Set (%s): empty output", permissionSetArn)
+		}
+
+		log.Printf("[WARN] Inline Policy for SSO Permission Set (%s) not found, removing from state", permissionSetArn)
+		d.SetId("")
+		return nil
+	}
+
+	policy, err := normalizePolicyJSON(aws.StringValue(output.InlinePolicy))
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error normalizing Inline Policy for SSO Permission Set (%s): %w", permissionSetArn, err))
+	}
+
+	d.Set("inline_policy", policy)
+	d.Set("instance_arn", instanceArn)
+	d.Set("permission_set_arn", permissionSetArn)
+
+	return nil
+}
+
+func resourceAwsSsoPermissionSetInlinePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	permissionSetArn, instanceArn, err := parseSsoPermissionSetInlinePolicyID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = conn.DeleteInlinePolicyFromPermissionSetWithContext(ctx, &ssoadmin.DeleteInlinePolicyFromPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+
+	if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Inline Policy for SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	return provisionPermissionSet(ctx, meta, instanceArn, permissionSetArn)
+}
+
+func parseSsoPermissionSetInlinePolicyID(id string) (string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format for ID (%q), expected PERMISSION_SET_ARN,INSTANCE_ARN", id)
+	}
+
+	return idParts[0], idParts[1], nil
+}
@@ -0,0 +1,173 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func testPermissionSetInlinePolicyState() *terraform.InstanceState {
+	return &terraform.InstanceState{
+		ID: "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111,arn:aws:sso:::instance/ssoins-1111111111111111",
+		Attributes: map[string]string{
+			"inline_policy":      `{"Statement":[{"Action":"s3:ListAllMyBuckets","Effect":"Allow","Resource":"*"}],"Version":"2012-10-17"}`,
+			"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+			"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+		},
+	}
+}
+
+func TestResourceAwsSsoPermissionSetInlinePolicy_normalizedDiff(t *testing.T) {
+	diff, err := testResourceDiff(resourceAwsSsoPermissionSetInlinePolicy(), testPermissionSetInlinePolicyState(), map[string]interface{}{
+		"inline_policy": `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "s3:ListAllMyBuckets",
+      "Resource": "*"
+    }
+  ]
+}`,
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	}, &AWSClient{})
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff for whitespace and key ordering changes, got: %#v", diff.Attributes)
+	}
+}
+
+func TestResourceAwsSsoPermissionSetInlinePolicy_emptyPolicy(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"GetInlinePolicyForPermissionSet": {{Body: map[string]interface{}{"InlinePolicy": ""}}},
+	})
+
+	r := resourceAwsSsoPermissionSetInlinePolicy()
+	d := r.Data(testPermissionSetInlinePolicyState())
+
+	if diags := resourceAwsSsoPermissionSetInlinePolicyRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("error reading resource: %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected resource with empty inline policy to be removed from state, got ID: %s", d.Id())
+	}
+
+	if got := len(api.Requests("GetInlinePolicyForPermissionSet")); got != 1 {
+		t.Errorf("got %d GetInlinePolicyForPermissionSet calls, expected 1", got)
+	}
+}
+
+func TestResourceAwsSsoPermissionSetInlinePolicy_deleteAlreadyRemoved(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"DeleteInlinePolicyFromPermissionSet": {{ErrorCode: "ResourceNotFoundException"}},
+	})
+
+	testResourceDestroy(t, resourceAwsSsoPermissionSetInlinePolicy(), testPermissionSetInlinePolicyState(), client)
+
+	if got := len(api.Requests("ProvisionPermissionSet")); got != 0 {
+		t.Errorf("got %d ProvisionPermissionSet calls, expected 0", got)
+	}
+}
+
+func TestResourceAwsSsoPermissionSetInlinePolicy_autoProvisionDisabled(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"PutInlinePolicyToPermissionSet": {{}},
+		"GetInlinePolicyForPermissionSet": {{Body: map[string]interface{}{
+			"InlinePolicy": `{"Statement":[{"Action":"s3:ListAllMyBuckets","Effect":"Allow","Resource":"*"}],"Version":"2012-10-17"}`,
+		}}},
+	})
+
+	client.skipAutoProvision = true
+
+	d := schema.TestResourceDataRaw(t, resourceAwsSsoPermissionSetInlinePolicy().Schema, map[string]interface{}{
+		"inline_policy":      `{"Statement":[{"Action":"s3:ListAllMyBuckets","Effect":"Allow","Resource":"*"}],"Version":"2012-10-17"}`,
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	})
+
+	diags := resourceAwsSsoPermissionSetInlinePolicyPut(context.Background(), d, client)
+
+	if diags.HasError() {
+		t.Fatalf("error putting inline policy: %v", diags)
+	}
+
+	if got, expected := len(diags), 1; got != expected {
+		t.Fatalf("got %d diagnostics, expected %d", got, expected)
+	}
+
+	if got, expected := diags[0].Severity, diag.Warning; got != expected {
+		t.Errorf("got severity %v, expected %v", got, expected)
+	}
+
+	if got, expected := len(api.Requests("ProvisionPermissionSet")), 0; got != expected {
+		t.Errorf("got %d ProvisionPermissionSet calls, expected %d", got, expected)
+	}
+}
+
+func TestResourceAwsSsoPermissionSetInlinePolicy_putNormalized(t *testing.T) {
+	var statements []interface{}
+
+	for i := 0; i < 200; i++ {
+		statements = append(statements, map[string]interface{}{
+			"Action":   "s3:GetObject",
+			"Effect":   "Allow",
+			"Resource": fmt.Sprintf("arn:aws:s3:::bucket-%03d/*", i),
+			"Sid":      fmt.Sprintf("S%03d", i),
+		})
+	}
+
+	b, err := json.MarshalIndent(map[string]interface{}{"Statement": statements, "Version": "2012-10-17"}, "", strings.Repeat(" ", 16))
+
+	if err != nil {
+		t.Fatalf("error encoding policy: %s", err)
+	}
+
+	raw := string(b)
+	policy, err := normalizePolicyJSON(raw)
+
+	if err != nil {
+		t.Fatalf("error normalizing policy: %s", err)
+	}
+
+	if len(raw) <= ssoInlinePolicyMaxLength || len(policy) > ssoInlinePolicyMaxLength {
+		t.Fatalf("expected only the configured policy (%d characters) to exceed the limit, normalized policy has %d characters", len(raw), len(policy))
+	}
+
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"PutInlinePolicyToPermissionSet":  {{}},
+		"GetInlinePolicyForPermissionSet": {{Body: map[string]interface{}{"InlinePolicy": policy}}},
+	})
+
+	client.skipAutoProvision = true
+
+	r := resourceAwsSsoPermissionSetInlinePolicy()
+
+	testResourceApply(t, r, nil, map[string]interface{}{
+		"inline_policy":      raw,
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	}, client)
+
+	requests := api.Requests("PutInlinePolicyToPermissionSet")
+
+	if got, expected := len(requests), 1; got != expected {
+		t.Fatalf("got %d PutInlinePolicyToPermissionSet calls, expected %d", got, expected)
+	}
+
+	if got := requests[0].Body["InlinePolicy"]; got != policy {
+		t.Errorf("expected normalized policy to be sent, got: %v", got)
+	}
+}
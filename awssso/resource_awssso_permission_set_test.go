@@ -0,0 +1,997 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/finder"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/service/ssoadmin/waiter"
+)
+
+func init() {
+	resource.AddTestSweepers("awssso_permission_set", &resource.Sweeper{
+		Name: "awssso_permission_set",
+		F:    testSweepSsoPermissionSets,
+	})
+}
+
+func testSweepSsoPermissionSets(region string) error {
+	client, err := sharedClientForRegion(region)
+
+	if err != nil {
+		return fmt.Errorf("error getting client: %w", err)
+	}
+
+	ctx := context.Background()
+	conn := client.SSOAdminConn()
+
+	instance, err := findSsoInstance(ctx, conn)
+
+	if err != nil {
+		return err
+	}
+
+	instanceArn := aws.StringValue(instance.InstanceArn)
+
+	permissionSetArns, err := finder.PermissionSetArns(ctx, conn, instanceArn)
+
+	if err != nil {
+		return fmt.Errorf("error listing SSO Permission Sets for instance (%s): %w", instanceArn, err)
+	}
+
+	var sweeperErrs *multierror.Error
+
+	for _, permissionSetArn := range permissionSetArns {
+		output, err := conn.DescribePermissionSetWithContext(ctx, &ssoadmin.DescribePermissionSetInput{
+			InstanceArn:      aws.String(instanceArn),
+			PermissionSetArn: aws.String(permissionSetArn),
+		})
+
+		if err != nil {
+			sweeperErrs = multierror.Append(sweeperErrs, fmt.Errorf("error reading SSO Permission Set (%s): %w", permissionSetArn, err))
+			continue
+		}
+
+		if output == nil || output.PermissionSet == nil || !strings.HasPrefix(aws.StringValue(output.PermissionSet.Name), sweeperNamePrefix) {
+			continue
+		}
+
+		// Permission sets cannot be deleted while assigned to accounts.
+		if err := testSweepSsoAccountAssignments(ctx, conn, instanceArn, permissionSetArn); err != nil {
+			sweeperErrs = multierror.Append(sweeperErrs, err)
+			continue
+		}
+
+		log.Printf("[INFO] Deleting SSO Permission Set: %s", permissionSetArn)
+
+		_, err = conn.DeletePermissionSetWithContext(ctx, &ssoadmin.DeletePermissionSetInput{
+			InstanceArn:      aws.String(instanceArn),
+			PermissionSetArn: aws.String(permissionSetArn),
+		})
+
+		if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+			continue
+		}
+
+		if err != nil {
+			sweeperErrs = multierror.Append(sweeperErrs, fmt.Errorf("error deleting SSO Permission Set (%s): %w", permissionSetArn, err))
+		}
+	}
+
+	return sweeperErrs.ErrorOrNil()
+}
+
+// testSweepSsoAccountAssignments deletes all account assignments of a
+// permission set in the accounts it is provisioned to.
+func testSweepSsoAccountAssignments(ctx context.Context, conn *ssoadmin.SSOAdmin, instanceArn, permissionSetArn string) error {
+	var accountIDs []string
+
+	err := conn.ListAccountsForProvisionedPermissionSetPagesWithContext(ctx, &ssoadmin.ListAccountsForProvisionedPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	}, func(page *ssoadmin.ListAccountsForProvisionedPermissionSetOutput, lastPage bool) bool {
+		if page != nil {
+			accountIDs = append(accountIDs, aws.StringValueSlice(page.AccountIds)...)
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return fmt.Errorf("error listing accounts for SSO Permission Set (%s): %w", permissionSetArn, err)
+	}
+
+	for _, accountID := range accountIDs {
+		accountAssignments, err := finder.AccountAssignments(ctx, conn, &ssoadmin.ListAccountAssignmentsInput{
+			AccountId:        aws.String(accountID),
+			InstanceArn:      aws.String(instanceArn),
+			PermissionSetArn: aws.String(permissionSetArn),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error listing SSO Account Assignments for SSO Permission Set (%s) in account (%s): %w", permissionSetArn, accountID, err)
+		}
+
+		for _, accountAssignment := range accountAssignments {
+			log.Printf("[INFO] Deleting SSO Account Assignment for %s (%s) in account (%s)", aws.StringValue(accountAssignment.PrincipalType), aws.StringValue(accountAssignment.PrincipalId), accountID)
+
+			output, err := conn.DeleteAccountAssignmentWithContext(ctx, &ssoadmin.DeleteAccountAssignmentInput{
+				InstanceArn:      aws.String(instanceArn),
+				PermissionSetArn: aws.String(permissionSetArn),
+				PrincipalId:      accountAssignment.PrincipalId,
+				PrincipalType:    accountAssignment.PrincipalType,
+				TargetId:         aws.String(accountID),
+				TargetType:       aws.String(ssoadmin.TargetTypeAwsAccount),
+			})
+
+			if err != nil {
+				return fmt.Errorf("error deleting SSO Account Assignment for %s (%s): %w", aws.StringValue(accountAssignment.PrincipalType), aws.StringValue(accountAssignment.PrincipalId), err)
+			}
+
+			if output == nil || output.AccountAssignmentDeletionStatus == nil {
+				continue
+			}
+
+			if _, err := waiter.AccountAssignmentDeleted(ctx, conn, instanceArn, aws.StringValue(output.AccountAssignmentDeletionStatus.RequestId), waiter.AccountAssignmentDeletedTimeout); err != nil {
+				return fmt.Errorf("error waiting for SSO Account Assignment for %s (%s) to be deleted: %w", aws.StringValue(accountAssignment.PrincipalType), aws.StringValue(accountAssignment.PrincipalId), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testPermissionSetResponse(description, sessionDuration string) map[string]interface{} {
+	return map[string]interface{}{
+		"PermissionSet": map[string]interface{}{
+			"CreatedDate":      1609459200,
+			"Description":      description,
+			"Name":             "test",
+			"PermissionSetArn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			"SessionDuration":  sessionDuration,
+		},
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_lifecycle(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreatePermissionSet": {{Body: testPermissionSetResponse("create", "PT1H")}},
+		"DescribePermissionSet": {
+			{Body: testPermissionSetResponse("create", "PT1H")},
+			{Body: testPermissionSetResponse("update", "PT1H")},
+		},
+		"UpdatePermissionSet":                     {{}},
+		"ProvisionPermissionSet":                  {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")}},
+		"DescribePermissionSetProvisioningStatus": {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")}},
+		"DeletePermissionSet":                     {{}},
+		"ListTagsForResource":                     {{Body: map[string]interface{}{"Tags": []interface{}{}}}},
+	})
+
+	r := resourceAwsSsoPermissionSet()
+	raw := map[string]interface{}{
+		"description":  "create",
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"name":         "test",
+	}
+
+	state := testResourceApply(t, r, nil, raw, client)
+
+	if got, expected := state.ID, "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"; got != expected {
+		t.Fatalf("got ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := state.Attributes["created_date"], "2021-01-01T00:00:00Z"; got != expected {
+		t.Errorf("got created_date %s, expected %s", got, expected)
+	}
+
+	if got, expected := state.Attributes["session_duration"], "PT1H"; got != expected {
+		t.Errorf("got session_duration %s, expected %s", got, expected)
+	}
+
+	raw["description"] = "update"
+	state = testResourceApply(t, r, state, raw, client)
+
+	if got, expected := state.Attributes["description"], "update"; got != expected {
+		t.Errorf("got description %s, expected %s", got, expected)
+	}
+
+	updates := api.Requests("UpdatePermissionSet")
+
+	if got, expected := len(updates), 1; got != expected {
+		t.Fatalf("got %d UpdatePermissionSet calls, expected %d", got, expected)
+	}
+
+	if _, ok := updates[0].Body["SessionDuration"]; ok {
+		t.Errorf("expected unchanged SessionDuration to be omitted from update, got: %v", updates[0].Body)
+	}
+
+	if _, ok := updates[0].Body["Name"]; ok {
+		t.Errorf("expected Name to be omitted from update, got: %v", updates[0].Body)
+	}
+
+	testResourceDestroy(t, r, state, client)
+
+	if got, expected := len(api.Requests("DeletePermissionSet")), 1; got != expected {
+		t.Errorf("got %d DeletePermissionSet calls, expected %d", got, expected)
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_defaultSessionDuration(t *testing.T) {
+	testCases := []struct {
+		TestName               string
+		DefaultSessionDuration string
+		SessionDuration        string
+		Expected               string
+	}{
+		{
+			TestName:               "provider default",
+			DefaultSessionDuration: "PT8H",
+			Expected:               "PT8H",
+		},
+		{
+			TestName:               "resource value wins",
+			DefaultSessionDuration: "PT8H",
+			SessionDuration:        "PT2H",
+			Expected:               "PT2H",
+		},
+		{
+			TestName: "no provider default",
+			Expected: "PT1H",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"CreatePermissionSet":   {{Body: testPermissionSetResponse("test", testCase.Expected)}},
+				"DescribePermissionSet": {{Body: testPermissionSetResponse("test", testCase.Expected)}},
+				"ListTagsForResource":   {{Body: map[string]interface{}{"Tags": []interface{}{}}}},
+			})
+			client.DefaultSessionDuration = testCase.DefaultSessionDuration
+
+			r := resourceAwsSsoPermissionSet()
+			raw := map[string]interface{}{
+				"description":  "test",
+				"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"name":         "test",
+			}
+
+			if testCase.SessionDuration != "" {
+				raw["session_duration"] = testCase.SessionDuration
+			}
+
+			state := testResourceApply(t, r, nil, raw, client)
+
+			creates := api.Requests("CreatePermissionSet")
+
+			if got, expected := len(creates), 1; got != expected {
+				t.Fatalf("got %d CreatePermissionSet calls, expected %d", got, expected)
+			}
+
+			if got := creates[0].Body["SessionDuration"]; got != testCase.Expected {
+				t.Errorf("got SessionDuration %v, expected %s", got, testCase.Expected)
+			}
+
+			if got := state.Attributes["session_duration"]; got != testCase.Expected {
+				t.Errorf("got session_duration %s, expected %s", got, testCase.Expected)
+			}
+
+			diff, err := testResourceDiff(r, state, raw, client)
+
+			if err != nil {
+				t.Fatalf("error planning resource: %s", err)
+			}
+
+			if diff != nil && !diff.Empty() {
+				t.Errorf("expected no diff after create, got: %#v", diff.Attributes)
+			}
+		})
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_updateInPlace(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreatePermissionSet": {{Body: testPermissionSetResponse("test", "PT1H")}},
+		"DescribePermissionSet": {
+			{Body: testPermissionSetResponse("test", "PT1H")},
+			{Body: testPermissionSetResponse("test", "PT2H")},
+		},
+		"UpdatePermissionSet":                     {{}},
+		"ProvisionPermissionSet":                  {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")}},
+		"DescribePermissionSetProvisioningStatus": {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")}},
+		"ListTagsForResource":                     {{Body: map[string]interface{}{"Tags": []interface{}{}}}},
+	})
+
+	r := resourceAwsSsoPermissionSet()
+	raw := map[string]interface{}{
+		"description":  "test",
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"name":         "test",
+	}
+
+	state := testResourceApply(t, r, nil, raw, client)
+
+	raw["session_duration"] = "PT2H"
+	diff, err := testResourceDiff(r, state, raw, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	if diff.RequiresNew() {
+		t.Fatalf("expected in-place update, got replacement: %v", diff)
+	}
+
+	state = testResourceApply(t, r, state, raw, client)
+
+	if got, expected := state.ID, "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := state.Attributes["provisioning_status"], ssoadmin.StatusValuesSucceeded; got != expected {
+		t.Errorf("got provisioning_status %q, expected %q", got, expected)
+	}
+
+	if got, expected := state.Attributes["failure_reason"], ""; got != expected {
+		t.Errorf("got failure_reason %q, expected %q", got, expected)
+	}
+
+	if got, expected := state.Attributes["session_duration"], "PT2H"; got != expected {
+		t.Errorf("got session_duration %s, expected %s", got, expected)
+	}
+
+	if got, expected := len(api.Requests("CreatePermissionSet")), 1; got != expected {
+		t.Errorf("got %d CreatePermissionSet calls, expected %d", got, expected)
+	}
+
+	updates := api.Requests("UpdatePermissionSet")
+
+	if got, expected := len(updates), 1; got != expected {
+		t.Fatalf("got %d UpdatePermissionSet calls, expected %d", got, expected)
+	}
+
+	if got, expected := updates[0].Body["SessionDuration"], "PT2H"; got != expected {
+		t.Errorf("got SessionDuration %v, expected %s", got, expected)
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_provisioningFailed(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"CreatePermissionSet": {{Body: testPermissionSetResponse("test", "PT1H")}},
+		"DescribePermissionSet": {
+			{Body: testPermissionSetResponse("test", "PT1H")},
+			{Body: testPermissionSetResponse("test", "PT2H")},
+		},
+		"UpdatePermissionSet":                     {{}},
+		"ProvisionPermissionSet":                  {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")}},
+		"DescribePermissionSetProvisioningStatus": {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesFailed, "account is not provisioned")}},
+		"ListTagsForResource":                     {{Body: map[string]interface{}{"Tags": []interface{}{}}}},
+	})
+
+	r := resourceAwsSsoPermissionSet()
+	raw := map[string]interface{}{
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"name":         "test",
+	}
+
+	state := testResourceApply(t, r, nil, raw, client)
+
+	if got, expected := state.Attributes["provisioning_status"], ""; got != expected {
+		t.Errorf("got provisioning_status %q after create, expected %q", got, expected)
+	}
+
+	if got, expected := state.Attributes["failure_reason"], ""; got != expected {
+		t.Errorf("got failure_reason %q after create, expected %q", got, expected)
+	}
+
+	raw["session_duration"] = "PT2H"
+	diff, err := testResourceDiff(r, state, raw, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	state, diags := r.Apply(context.Background(), state, diff, client)
+
+	if !diags.HasError() {
+		t.Fatal("expected error applying resource, got none")
+	}
+
+	if got, expected := state.Attributes["provisioning_status"], ssoadmin.StatusValuesFailed; got != expected {
+		t.Errorf("got provisioning_status %q, expected %q", got, expected)
+	}
+
+	if got, expected := state.Attributes["failure_reason"], "account is not provisioned"; got != expected {
+		t.Errorf("got failure_reason %q, expected %q", got, expected)
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_provisioningRequestFailed(t *testing.T) {
+	client, _ := testMockClient(t, map[string][]mockapi.Response{
+		"CreatePermissionSet": {{Body: testPermissionSetResponse("test", "PT1H")}},
+		"DescribePermissionSet": {
+			{Body: testPermissionSetResponse("test", "PT1H")},
+			{Body: testPermissionSetResponse("test", "PT2H")},
+		},
+		"UpdatePermissionSet":    {{}},
+		"ProvisionPermissionSet": {{ErrorCode: ssoadmin.ErrCodeAccessDeniedException}},
+		"ListTagsForResource":    {{Body: map[string]interface{}{"Tags": []interface{}{}}}},
+	})
+
+	r := resourceAwsSsoPermissionSet()
+	raw := map[string]interface{}{
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"name":         "test",
+	}
+
+	state := testResourceApply(t, r, nil, raw, client)
+
+	raw["session_duration"] = "PT2H"
+	diff, err := testResourceDiff(r, state, raw, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	state, diags := r.Apply(context.Background(), state, diff, client)
+
+	if !diags.HasError() {
+		t.Fatal("expected error applying resource, got none")
+	}
+
+	if got, expected := state.Attributes["provisioning_status"], ssoadmin.StatusValuesFailed; got != expected {
+		t.Errorf("got provisioning_status %q, expected %q", got, expected)
+	}
+
+	if got := state.Attributes["failure_reason"]; !strings.Contains(got, ssoadmin.ErrCodeAccessDeniedException) {
+		t.Errorf("got failure_reason %q, expected it to contain %q", got, ssoadmin.ErrCodeAccessDeniedException)
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_createEventualConsistency(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreatePermissionSet": {{Body: testPermissionSetResponse("test", "PT1H")}},
+		"DescribePermissionSet": {
+			{ErrorCode: ssoadmin.ErrCodeResourceNotFoundException},
+			{Body: testPermissionSetResponse("test", "PT1H")},
+		},
+		"ListTagsForResource": {{Body: map[string]interface{}{"Tags": []interface{}{}}}},
+	})
+
+	state := testResourceApply(t, resourceAwsSsoPermissionSet(), nil, map[string]interface{}{
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"name":         "test",
+	}, client)
+
+	if got, expected := state.ID, "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+
+	if got, expected := len(api.Requests("DescribePermissionSet")), 2; got != expected {
+		t.Errorf("got %d DescribePermissionSet calls, expected %d", got, expected)
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_createTags(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreatePermissionSet":   {{Body: testPermissionSetResponse("test", "PT1H")}},
+		"DescribePermissionSet": {{Body: testPermissionSetResponse("test", "PT1H")}},
+		"ListTagsForResource": {{Body: map[string]interface{}{"Tags": []interface{}{
+			map[string]interface{}{"Key": "Team", "Value": "Platform"},
+		}}}},
+		"TagResource": {{}},
+	})
+
+	state := testResourceApply(t, resourceAwsSsoPermissionSet(), nil, map[string]interface{}{
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"name":         "test",
+		"tags": map[string]interface{}{
+			"Team": "Platform",
+		},
+	}, client)
+
+	creates := api.Requests("CreatePermissionSet")
+
+	if got, expected := len(creates), 1; got != expected {
+		t.Fatalf("got %d CreatePermissionSet calls, expected %d", got, expected)
+	}
+
+	expectedTags := []interface{}{
+		map[string]interface{}{"Key": "Team", "Value": "Platform"},
+	}
+
+	if got := creates[0].Body["Tags"]; !reflect.DeepEqual(got, expectedTags) {
+		t.Errorf("got Tags %v, expected %v", got, expectedTags)
+	}
+
+	if got := len(api.Requests("TagResource")); got != 0 {
+		t.Errorf("got %d TagResource calls, expected none", got)
+	}
+
+	if got, expected := state.Attributes["tags_all.Team"], "Platform"; got != expected {
+		t.Errorf("got tags_all.Team %s, expected %s", got, expected)
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_removeDescription(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreatePermissionSet": {{Body: testPermissionSetResponse("test", "PT1H")}},
+		"DescribePermissionSet": {
+			{Body: testPermissionSetResponse("test", "PT1H")},
+			{Body: testPermissionSetResponse("", "PT1H")},
+		},
+		"UpdatePermissionSet":                     {{}},
+		"ProvisionPermissionSet":                  {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")}},
+		"DescribePermissionSetProvisioningStatus": {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")}},
+		"ListTagsForResource":                     {{Body: map[string]interface{}{"Tags": []interface{}{}}}},
+	})
+
+	r := resourceAwsSsoPermissionSet()
+	raw := map[string]interface{}{
+		"description":  "test",
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"name":         "test",
+	}
+
+	state := testResourceApply(t, r, nil, raw, client)
+
+	delete(raw, "description")
+	state = testResourceApply(t, r, state, raw, client)
+
+	updates := api.Requests("UpdatePermissionSet")
+
+	if got, expected := len(updates), 1; got != expected {
+		t.Fatalf("got %d UpdatePermissionSet calls, expected %d", got, expected)
+	}
+
+	if got, ok := updates[0].Body["Description"]; !ok || got != "" {
+		t.Errorf("expected empty Description in update, got: %v", updates[0].Body)
+	}
+
+	if got := state.Attributes["description"]; got != "" {
+		t.Errorf("got description %s, expected it to be removed", got)
+	}
+
+	diff, err := testResourceDiff(r, state, raw, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff, got: %v", diff)
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_emptyDescription(t *testing.T) {
+	absent := testPermissionSetResponse("", "PT1H")
+	delete(absent["PermissionSet"].(map[string]interface{}), "Description")
+
+	testCases := []struct {
+		TestName string
+		Response map[string]interface{}
+	}{
+		{
+			TestName: "absent",
+			Response: absent,
+		},
+		{
+			TestName: "empty",
+			Response: testPermissionSetResponse("", "PT1H"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, _ := testMockClient(t, map[string][]mockapi.Response{
+				"CreatePermissionSet":   {{Body: testCase.Response}},
+				"DescribePermissionSet": {{Body: testCase.Response}},
+				"ListTagsForResource":   {{Body: map[string]interface{}{"Tags": []interface{}{}}}},
+			})
+
+			r := resourceAwsSsoPermissionSet()
+			raw := map[string]interface{}{
+				"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"name":         "test",
+			}
+
+			state := testResourceApply(t, r, nil, raw, client)
+			diff, err := testResourceDiff(r, state, raw, client)
+
+			if err != nil {
+				t.Fatalf("error planning resource: %s", err)
+			}
+
+			if diff != nil && !diff.Empty() {
+				t.Errorf("expected no diff for an unset description, got: %#v", diff.Attributes)
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentIso8601Durations(t *testing.T) {
+	testCases := []struct {
+		Old      string
+		New      string
+		Expected bool
+	}{
+		{Old: "PT1H", New: "PT60M", Expected: true},
+		{Old: "PT1H30M", New: "PT90M", Expected: true},
+		{Old: "PT1H", New: "PT2H", Expected: false},
+		{Old: "PT1H", New: "invalid", Expected: false},
+	}
+
+	for _, testCase := range testCases {
+		if got := suppressEquivalentIso8601Durations("session_duration", testCase.Old, testCase.New, nil); got != testCase.Expected {
+			t.Errorf("got %t for %q => %q, expected %t", got, testCase.Old, testCase.New, testCase.Expected)
+		}
+	}
+}
+
+func TestResourceAwsSsoPermissionSet_tagsDiff(t *testing.T) {
+	client := &AWSClient{
+		DefaultTagsConfig: &keyvaluetags.DefaultConfig{
+			Tags: keyvaluetags.New(map[string]interface{}{
+				"Environment": "test",
+				"Owner":       "default",
+			}),
+		},
+		IgnoreTagsConfig: &keyvaluetags.IgnoreConfig{
+			Keys: keyvaluetags.New([]interface{}{"Ignored"}),
+		},
+	}
+
+	diff, err := testResourceDiff(resourceAwsSsoPermissionSet(), nil, map[string]interface{}{
+		"instance_arn": "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"name":         "test",
+		"tags": map[string]interface{}{
+			"Ignored": "value",
+			"Owner":   "resource",
+		},
+	}, client)
+
+	if err != nil {
+		t.Fatalf("error planning resource: %s", err)
+	}
+
+	expected := map[string]string{
+		"tags_all.%":           "2",
+		"tags_all.Environment": "test",
+		"tags_all.Owner":       "resource",
+	}
+
+	for k, v := range expected {
+		attr, ok := diff.Attributes[k]
+
+		if !ok {
+			t.Errorf("expected %s in diff", k)
+			continue
+		}
+
+		if attr.New != v {
+			t.Errorf("got %s = %s, expected %s", k, attr.New, v)
+		}
+	}
+
+	if _, ok := diff.Attributes["tags_all.Ignored"]; ok {
+		t.Errorf("expected tags_all.Ignored to be filtered from diff")
+	}
+}
+
+func testPermissionSetProvisioningStatus(status, failureReason string) map[string]interface{} {
+	return map[string]interface{}{
+		"PermissionSetProvisioningStatus": map[string]interface{}{
+			"FailureReason": failureReason,
+			"RequestId":     "11111111-2222-3333-4444-555555555555",
+			"Status":        status,
+		},
+	}
+}
+
+func TestProvisionAndWait(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Status        string
+		FailureReason string
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName: "succeeded",
+			Status:   ssoadmin.StatusValuesSucceeded,
+		},
+		{
+			TestName:      "failed",
+			Status:        ssoadmin.StatusValuesFailed,
+			FailureReason: "account is not provisioned",
+			ExpectedError: regexp.MustCompile(`account is not provisioned`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"ProvisionPermissionSet":                  {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")}},
+				"DescribePermissionSetProvisioningStatus": {{Body: testPermissionSetProvisioningStatus(testCase.Status, testCase.FailureReason)}},
+			})
+
+			_, err := provisionAndWait(context.Background(), client.SSOAdminConn(), "arn:aws:sso:::instance/ssoins-1111111111111111", "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111")
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+
+			provisions := api.Requests("ProvisionPermissionSet")
+
+			if got, expected := len(provisions), 1; got != expected {
+				t.Fatalf("got %d ProvisionPermissionSet calls, expected %d", got, expected)
+			}
+
+			if got, expected := provisions[0].Body["TargetType"], ssoadmin.ProvisionTargetTypeAllProvisionedAccounts; got != expected {
+				t.Errorf("got TargetType %v, expected %s", got, expected)
+			}
+
+			statuses := api.Requests("DescribePermissionSetProvisioningStatus")
+
+			if got, expected := len(statuses), 1; got != expected {
+				t.Fatalf("got %d DescribePermissionSetProvisioningStatus calls, expected %d", got, expected)
+			}
+
+			if got, expected := statuses[0].Body["ProvisionPermissionSetRequestId"], "11111111-2222-3333-4444-555555555555"; got != expected {
+				t.Errorf("got ProvisionPermissionSetRequestId %v, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestResourceAwsSsoPermissionSetImport(t *testing.T) {
+	testCases := []struct {
+		TestName            string
+		ID                  string
+		ExpectedID          string
+		ExpectedInstanceArn string
+		ExpectedError       *regexp.Regexp
+	}{
+		{
+			TestName:            "valid",
+			ID:                  "arn:aws:sso:::instance/ssoins-1111111111111111,arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			ExpectedID:          "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			ExpectedInstanceArn: "arn:aws:sso:::instance/ssoins-1111111111111111",
+		},
+		{
+			TestName:      "permission set ARN only",
+			ID:            "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			ExpectedError: regexp.MustCompile(`expected INSTANCE_ARN,PERMISSION_SET_ARN`),
+		},
+		{
+			TestName:      "not ARNs",
+			ID:            "ssoins-1111111111111111,ps-1111111111111111",
+			ExpectedError: regexp.MustCompile(`expected INSTANCE_ARN,PERMISSION_SET_ARN`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceAwsSsoPermissionSet().Schema, map[string]interface{}{})
+			d.SetId(testCase.ID)
+
+			results, err := resourceAwsSsoPermissionSetImport(context.Background(), d, nil)
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil {
+				if !testCase.ExpectedError.MatchString(err.Error()) {
+					t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+				}
+
+				return
+			}
+
+			if got, expected := len(results), 1; got != expected {
+				t.Fatalf("got %d results, expected %d", got, expected)
+			}
+
+			if got, expected := results[0].Id(), testCase.ExpectedID; got != expected {
+				t.Errorf("got ID %s, expected %s", got, expected)
+			}
+
+			if got, expected := results[0].Get("instance_arn").(string), testCase.ExpectedInstanceArn; got != expected {
+				t.Errorf("got instance_arn %s, expected %s", got, expected)
+			}
+		})
+	}
+}
+
+func TestAWSClientProvisionPermissionSet_collapsesChanges(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"AttachCustomerManagedPolicyReferenceToPermissionSet": {{}},
+		"ListCustomerManagedPolicyReferencesInPermissionSet": {{Body: map[string]interface{}{
+			"CustomerManagedPolicyReferences": []interface{}{
+				map[string]interface{}{"Name": "test", "Path": "/"},
+			},
+		}}},
+		"PutInlinePolicyToPermissionSet":          {{}},
+		"GetInlinePolicyForPermissionSet":         {{Body: map[string]interface{}{"InlinePolicy": `{"Version":"2012-10-17","Statement":[]}`}}},
+		"ProvisionPermissionSet":                  {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")}},
+		"DescribePermissionSetProvisioningStatus": {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")}},
+	})
+
+	client.provisioner.delay = 500 * time.Millisecond
+
+	inlinePolicy := schema.TestResourceDataRaw(t, resourceAwsSsoPermissionSetInlinePolicy().Schema, map[string]interface{}{
+		"inline_policy":      `{"Version":"2012-10-17","Statement":[]}`,
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	})
+
+	attachment := schema.TestResourceDataRaw(t, resourceAwsSsoCustomerManagedPolicyAttachment().Schema, map[string]interface{}{
+		"customer_managed_policy_reference": []interface{}{
+			map[string]interface{}{"name": "test"},
+		},
+		"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+		"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+	})
+
+	var wg sync.WaitGroup
+	results := make([]diag.Diagnostics, 2)
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		results[0] = resourceAwsSsoPermissionSetInlinePolicyPut(context.Background(), inlinePolicy, client)
+	}()
+
+	go func() {
+		defer wg.Done()
+		results[1] = resourceAwsSsoCustomerManagedPolicyAttachmentCreate(context.Background(), attachment, client)
+	}()
+
+	wg.Wait()
+
+	for _, diags := range results {
+		if diags.HasError() {
+			t.Fatalf("error applying resource: %v", diags)
+		}
+	}
+
+	if got, expected := len(api.Requests("ProvisionPermissionSet")), 1; got != expected {
+		t.Errorf("got %d ProvisionPermissionSet calls, expected %d", got, expected)
+	}
+}
+
+func TestAWSClientProvisionPermissionSet_leaderCancelled(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"ProvisionPermissionSet":                  {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")}},
+		"DescribePermissionSetProvisioningStatus": {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")}},
+	})
+
+	client.provisioner.delay = 500 * time.Millisecond
+
+	instanceArn := "arn:aws:sso:::instance/ssoins-1111111111111111"
+	permissionSetArn := "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111"
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var leaderErr, joinerErr error
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		_, leaderErr = client.ProvisionPermissionSet(leaderCtx, instanceArn, permissionSetArn)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	go func() {
+		defer wg.Done()
+		_, joinerErr = client.ProvisionPermissionSet(context.Background(), instanceArn, permissionSetArn)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	wg.Wait()
+
+	if !errors.Is(leaderErr, context.Canceled) {
+		t.Errorf("expected cancelled provision to return %s, got: %v", context.Canceled, leaderErr)
+	}
+
+	if joinerErr != nil {
+		t.Fatalf("error provisioning joined permission set: %s", joinerErr)
+	}
+
+	if got, expected := len(api.Requests("ProvisionPermissionSet")), 1; got != expected {
+		t.Errorf("got %d ProvisionPermissionSet calls, expected %d", got, expected)
+	}
+}
+
+func TestProvisionAndWait_conflict(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		MaxRetries    int
+		Responses     []mockapi.Response
+		ExpectedError *regexp.Regexp
+		ExpectedCalls int
+	}{
+		{
+			TestName:   "conflict then success",
+			MaxRetries: 2,
+			Responses: []mockapi.Response{
+				{ErrorCode: ssoadmin.ErrCodeConflictException, StatusCode: 409},
+				{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesInProgress, "")},
+			},
+			ExpectedCalls: 2,
+		},
+		{
+			TestName:   "retries exhausted",
+			MaxRetries: 1,
+			Responses: []mockapi.Response{
+				{ErrorCode: ssoadmin.ErrCodeConflictException, StatusCode: 409},
+			},
+			ExpectedError: regexp.MustCompile(`ConflictException`),
+			ExpectedCalls: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			api := mockapi.New(t, map[string][]mockapi.Response{
+				"ProvisionPermissionSet":                  testCase.Responses,
+				"DescribePermissionSetProvisioningStatus": {{Body: testPermissionSetProvisioningStatus(ssoadmin.StatusValuesSucceeded, "")}},
+			})
+			conn := ssoadmin.New(api.Session().Copy(&aws.Config{MaxRetries: aws.Int(testCase.MaxRetries)}))
+
+			_, err := provisionAndWait(context.Background(), conn, "arn:aws:sso:::instance/ssoins-1111111111111111", "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111")
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+
+			if got, expected := len(api.Requests("ProvisionPermissionSet")), testCase.ExpectedCalls; got != expected {
+				t.Errorf("got %d ProvisionPermissionSet calls, expected %d", got, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAwsSsoPermissionsBoundary() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoPermissionsBoundaryPut,
+		ReadContext:   resourceAwsSsoPermissionsBoundaryRead,
+		UpdateContext: resourceAwsSsoPermissionsBoundaryPut,
+		DeleteContext: resourceAwsSsoPermissionsBoundaryDelete,
+
+		Schema: map[string]*schema.Schema{
+			"customer_managed_policy_reference": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"customer_managed_policy_reference", "managed_policy_arn"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 128),
+						},
+						"path": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "/",
+							ValidateFunc: validation.StringLenBetween(1, 512),
+						},
+					},
+				},
+			},
+			"instance_arn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+			"managed_policy_arn": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"customer_managed_policy_reference", "managed_policy_arn"},
+				ValidateFunc:     validateArn,
+				DiffSuppressFunc: suppressEquivalentManagedPolicyArnDiffs,
+			},
+			"permission_set_arn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArn,
+			},
+		},
+	}
+}
+
+func resourceAwsSsoPermissionsBoundaryPut(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	instanceArn, err := ssoInstanceArn(ctx, d, conn)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissionSetArn := d.Get("permission_set_arn").(string)
+
+	boundary := &ssoadmin.PermissionsBoundary{
+		CustomerManagedPolicyReference: expandSsoCustomerManagedPolicyReference(d.Get("customer_managed_policy_reference").([]interface{})),
+	}
+
+	if v, ok := d.GetOk("managed_policy_arn"); ok {
+		boundary.ManagedPolicyArn = aws.String(managedPolicyArnInPartition(v.(string), meta.(*AWSClient).Partition()))
+	}
+
+	input := &ssoadmin.PutPermissionsBoundaryToPermissionSetInput{
+		InstanceArn:         aws.String(instanceArn),
+		PermissionSetArn:    aws.String(permissionSetArn),
+		PermissionsBoundary: boundary,
+	}
+
+	_, err = conn.PutPermissionsBoundaryToPermissionSetWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error putting Permissions Boundary for SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s", permissionSetArn, instanceArn))
+
+	diags := provisionPermissionSet(ctx, meta, instanceArn, permissionSetArn)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceAwsSsoPermissionsBoundaryRead(ctx, d, meta)...)
+}
+
+func resourceAwsSsoPermissionsBoundaryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	permissionSetArn, instanceArn, err := parseSsoPermissionsBoundaryID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	output, err := conn.GetPermissionsBoundaryForPermissionSetWithContext(ctx, &ssoadmin.GetPermissionsBoundaryForPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] Permissions Boundary for SSO Permission Set (%s) not found, removing from state", permissionSetArn)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Permissions Boundary for SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	if output == nil || output.PermissionsBoundary == nil {
+		return diag.Errorf("error reading Permissions Boundary for SSO Permission Set (%s): empty output", permissionSetArn)
+	}
+
+	boundary := output.PermissionsBoundary
+
+	var reference []interface{}
+	if boundary.CustomerManagedPolicyReference != nil {
+		reference = flattenSsoCustomerManagedPolicyReference(boundary.CustomerManagedPolicyReference)
+	}
+
+	if err := d.Set("customer_managed_policy_reference", reference); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting customer_managed_policy_reference: %w", err))
+	}
+
+	d.Set("instance_arn", instanceArn)
+	d.Set("managed_policy_arn", boundary.ManagedPolicyArn)
+	d.Set("permission_set_arn", permissionSetArn)
+
+	return nil
+}
+
+func resourceAwsSsoPermissionsBoundaryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).SSOAdminConn()
+
+	permissionSetArn, instanceArn, err := parseSsoPermissionsBoundaryID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = conn.DeletePermissionsBoundaryFromPermissionSetWithContext(ctx, &ssoadmin.DeletePermissionsBoundaryFromPermissionSetInput{
+		InstanceArn:      aws.String(instanceArn),
+		PermissionSetArn: aws.String(permissionSetArn),
+	})
+
+	if tfawserr.ErrCodeEquals(err, ssoadmin.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Permissions Boundary from SSO Permission Set (%s): %w", permissionSetArn, cleanAwsRequestError(err)))
+	}
+
+	return provisionPermissionSet(ctx, meta, instanceArn, permissionSetArn)
+}
+
+func parseSsoPermissionsBoundaryID(id string) (string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format for ID (%q), expected PERMISSION_SET_ARN,INSTANCE_ARN", id)
+	}
+
+	return idParts[0], idParts[1], nil
+}
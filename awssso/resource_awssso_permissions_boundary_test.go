@@ -0,0 +1,145 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func TestResourceAwsSsoPermissionsBoundary_validation(t *testing.T) {
+	testCases := []struct {
+		TestName    string
+		Config      map[string]interface{}
+		ExpectError bool
+	}{
+		{
+			TestName: "managed policy",
+			Config: map[string]interface{}{
+				"managed_policy_arn": "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			},
+		},
+		{
+			TestName: "customer managed policy reference",
+			Config: map[string]interface{}{
+				"customer_managed_policy_reference": []interface{}{
+					map[string]interface{}{"name": "test"},
+				},
+			},
+		},
+		{
+			TestName: "both",
+			Config: map[string]interface{}{
+				"customer_managed_policy_reference": []interface{}{
+					map[string]interface{}{"name": "test"},
+				},
+				"managed_policy_arn": "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			},
+			ExpectError: true,
+		},
+		{
+			TestName:    "neither",
+			Config:      map[string]interface{}{},
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			}
+
+			for k, v := range testCase.Config {
+				raw[k] = v
+			}
+
+			diags := resourceAwsSsoPermissionsBoundary().Validate(terraform.NewResourceConfigRaw(raw))
+
+			if got := diags.HasError(); got != testCase.ExpectError {
+				t.Errorf("got error %t, expected %t: %v", got, testCase.ExpectError, diags)
+			}
+		})
+	}
+}
+
+func TestResourceAwsSsoPermissionsBoundary_put(t *testing.T) {
+	testCases := []struct {
+		TestName         string
+		Config           map[string]interface{}
+		Boundary         map[string]interface{}
+		ExpectedBoundary map[string]interface{}
+	}{
+		{
+			TestName: "managed policy",
+			Config: map[string]interface{}{
+				"managed_policy_arn": "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			},
+			Boundary: map[string]interface{}{
+				"ManagedPolicyArn": "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			},
+			ExpectedBoundary: map[string]interface{}{
+				"ManagedPolicyArn": "arn:aws:iam::aws:policy/ReadOnlyAccess",
+			},
+		},
+		{
+			TestName: "customer managed policy reference",
+			Config: map[string]interface{}{
+				"customer_managed_policy_reference": []interface{}{
+					map[string]interface{}{"name": "test"},
+				},
+			},
+			Boundary: map[string]interface{}{
+				"CustomerManagedPolicyReference": map[string]interface{}{"Name": "test", "Path": "/"},
+			},
+			ExpectedBoundary: map[string]interface{}{
+				"CustomerManagedPolicyReference": map[string]interface{}{"Name": "test", "Path": "/"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			provisioningStatus := map[string]interface{}{
+				"PermissionSetProvisioningStatus": map[string]interface{}{
+					"RequestId": "11111111-2222-3333-4444-555555555555",
+					"Status":    "SUCCEEDED",
+				},
+			}
+
+			client, api := testMockClient(t, map[string][]mockapi.Response{
+				"PutPermissionsBoundaryToPermissionSet":   {{}},
+				"GetPermissionsBoundaryForPermissionSet":  {{Body: map[string]interface{}{"PermissionsBoundary": testCase.Boundary}}},
+				"ProvisionPermissionSet":                  {{Body: provisioningStatus}},
+				"DescribePermissionSetProvisioningStatus": {{Body: provisioningStatus}},
+			})
+
+			raw := map[string]interface{}{
+				"instance_arn":       "arn:aws:sso:::instance/ssoins-1111111111111111",
+				"permission_set_arn": "arn:aws:sso:::permissionSet/ssoins-1111111111111111/ps-1111111111111111",
+			}
+
+			for k, v := range testCase.Config {
+				raw[k] = v
+			}
+
+			testResourceApply(t, resourceAwsSsoPermissionsBoundary(), nil, raw, client)
+
+			puts := api.Requests("PutPermissionsBoundaryToPermissionSet")
+
+			if got, expected := len(puts), 1; got != expected {
+				t.Fatalf("got %d put calls, expected %d", got, expected)
+			}
+
+			if got := puts[0].Body["PermissionsBoundary"]; !reflect.DeepEqual(got, testCase.ExpectedBoundary) {
+				t.Errorf("got boundary %v, expected %v", got, testCase.ExpectedBoundary)
+			}
+
+			if got, expected := len(api.Requests("ProvisionPermissionSet")), 1; got != expected {
+				t.Errorf("got %d ProvisionPermissionSet calls, expected %d", got, expected)
+			}
+		})
+	}
+}
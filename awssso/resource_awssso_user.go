@@ -0,0 +1,366 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tfidentitystore "github.com/takescoop/terraform-provider-awssso/awssso/internal/service/identitystore"
+)
+
+func resourceAwsSsoUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAwsSsoUserCreate,
+		ReadContext:   resourceAwsSsoUserRead,
+		UpdateContext: resourceAwsSsoUserUpdate,
+		DeleteContext: resourceAwsSsoUserDelete,
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 1024),
+			},
+			"emails": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"primary": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(1, 1024),
+						},
+						"value": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 1024),
+						},
+					},
+				},
+			},
+			"identity_store_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 36),
+			},
+			"name": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"family_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 1024),
+						},
+						"given_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 1024),
+						},
+					},
+				},
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+		},
+	}
+}
+
+func resourceAwsSsoUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	identityStoreID := d.Get("identity_store_id").(string)
+	userName := d.Get("user_name").(string)
+
+	input := &identitystore.CreateUserInput{
+		DisplayName:     aws.String(d.Get("display_name").(string)),
+		IdentityStoreId: aws.String(identityStoreID),
+		Name:            expandIdentityStoreName(d.Get("name").([]interface{})),
+		UserName:        aws.String(userName),
+	}
+
+	if v, ok := d.GetOk("emails"); ok {
+		emails, err := expandIdentityStoreEmails(v.([]interface{}))
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		input.Emails = emails
+	}
+
+	output, err := conn.CreateUserWithContext(ctx, input)
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating Identity Store User (%s): %w", userName, cleanAwsRequestError(err)))
+	}
+
+	if output == nil || output.UserId == nil {
+		return diag.Errorf("error creating Identity Store User (%s): empty output", userName)
+	}
+
+	d.SetId(fmt.Sprintf("%s,%s", aws.StringValue(output.UserId), identityStoreID))
+
+	return resourceAwsSsoUserRead(ctx, d, meta)
+}
+
+func resourceAwsSsoUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	userID, identityStoreID, err := parseSsoUserID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	output, err := conn.DescribeUserWithContext(ctx, &identitystore.DescribeUserInput{
+		IdentityStoreId: aws.String(identityStoreID),
+		UserId:          aws.String(userID),
+	})
+
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, identitystore.ErrCodeResourceNotFoundException) {
+		log.Printf("[WARN] Identity Store User (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Identity Store User (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	if output == nil {
+		return diag.Errorf("error reading Identity Store User (%s): empty output", d.Id())
+	}
+
+	d.Set("display_name", output.DisplayName)
+
+	if err := d.Set("emails", flattenIdentityStoreEmails(output.Emails)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting emails: %w", err))
+	}
+
+	d.Set("identity_store_id", output.IdentityStoreId)
+
+	if err := d.Set("name", flattenIdentityStoreName(output.Name)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting name: %w", err))
+	}
+
+	d.Set("user_id", output.UserId)
+	d.Set("user_name", output.UserName)
+
+	return nil
+}
+
+func resourceAwsSsoUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	userID, identityStoreID, err := parseSsoUserID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := &tfidentitystore.UpdateUserInput{
+		IdentityStoreId: aws.String(identityStoreID),
+		UserId:          aws.String(userID),
+	}
+
+	if d.HasChange("display_name") {
+		input.Operations = append(input.Operations, &tfidentitystore.AttributeOperation{
+			AttributePath:  aws.String("displayName"),
+			AttributeValue: d.Get("display_name").(string),
+		})
+	}
+
+	if d.HasChange("emails") {
+		operation := &tfidentitystore.AttributeOperation{
+			AttributePath: aws.String("emails"),
+		}
+
+		emails, err := expandIdentityStoreEmails(d.Get("emails").([]interface{}))
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if len(emails) > 0 {
+			operation.AttributeValue = identityStoreEmailsAttributeValue(emails)
+		}
+
+		input.Operations = append(input.Operations, operation)
+	}
+
+	if d.HasChange("name.0.family_name") {
+		input.Operations = append(input.Operations, &tfidentitystore.AttributeOperation{
+			AttributePath:  aws.String("name.familyName"),
+			AttributeValue: d.Get("name.0.family_name").(string),
+		})
+	}
+
+	if d.HasChange("name.0.given_name") {
+		input.Operations = append(input.Operations, &tfidentitystore.AttributeOperation{
+			AttributePath:  aws.String("name.givenName"),
+			AttributeValue: d.Get("name.0.given_name").(string),
+		})
+	}
+
+	if d.HasChange("user_name") {
+		input.Operations = append(input.Operations, &tfidentitystore.AttributeOperation{
+			AttributePath:  aws.String("userName"),
+			AttributeValue: d.Get("user_name").(string),
+		})
+	}
+
+	if len(input.Operations) > 0 {
+		if _, err := tfidentitystore.UpdateUser(ctx, conn, input); err != nil {
+			return diag.FromErr(fmt.Errorf("error updating Identity Store User (%s): %w", d.Id(), cleanAwsRequestError(err)))
+		}
+	}
+
+	return resourceAwsSsoUserRead(ctx, d, meta)
+}
+
+func resourceAwsSsoUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*AWSClient).IdentityStoreConn()
+
+	userID, identityStoreID, err := parseSsoUserID(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = conn.DeleteUserWithContext(ctx, &identitystore.DeleteUserInput{
+		IdentityStoreId: aws.String(identityStoreID),
+		UserId:          aws.String(userID),
+	})
+
+	if tfawserr.ErrCodeEquals(err, identitystore.ErrCodeResourceNotFoundException) {
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting Identity Store User (%s): %w", d.Id(), cleanAwsRequestError(err)))
+	}
+
+	return nil
+}
+
+func parseSsoUserID(id string) (string, string, error) {
+	idParts := strings.Split(id, ",")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format for ID (%q), expected USER_ID,IDENTITY_STORE_ID", id)
+	}
+
+	return idParts[0], idParts[1], nil
+}
+
+// expandIdentityStoreEmails expands user emails, flagging the first email as
+// primary when none is, as a user with emails has exactly one primary email.
+func expandIdentityStoreEmails(l []interface{}) ([]*identitystore.Email, error) {
+	var emails []*identitystore.Email
+	var primary int
+
+	for _, v := range l {
+		m, ok := v.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		email := &identitystore.Email{
+			Primary: aws.Bool(m["primary"].(bool)),
+			Value:   aws.String(m["value"].(string)),
+		}
+
+		if v, ok := m["type"].(string); ok && v != "" {
+			email.Type = aws.String(v)
+		}
+
+		if aws.BoolValue(email.Primary) {
+			primary++
+		}
+
+		emails = append(emails, email)
+	}
+
+	if primary > 1 {
+		return nil, errors.New("only one email can be primary")
+	}
+
+	if primary == 0 && len(emails) > 0 {
+		emails[0].Primary = aws.Bool(true)
+	}
+
+	return emails, nil
+}
+
+// identityStoreEmailsAttributeValue returns emails as an UpdateUser attribute
+// value, leaving out unset members rather than sending them as null.
+func identityStoreEmailsAttributeValue(emails []*identitystore.Email) []interface{} {
+	var results []interface{}
+
+	for _, email := range emails {
+		value := map[string]interface{}{
+			"Primary": aws.BoolValue(email.Primary),
+			"Value":   aws.StringValue(email.Value),
+		}
+
+		if email.Type != nil {
+			value["Type"] = aws.StringValue(email.Type)
+		}
+
+		results = append(results, value)
+	}
+
+	return results
+}
+
+func expandIdentityStoreName(l []interface{}) *identitystore.Name {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	m := l[0].(map[string]interface{})
+
+	return &identitystore.Name{
+		FamilyName: aws.String(m["family_name"].(string)),
+		GivenName:  aws.String(m["given_name"].(string)),
+	}
+}
+
+func flattenIdentityStoreName(name *identitystore.Name) []interface{} {
+	if name == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"family_name": aws.StringValue(name.FamilyName),
+			"given_name":  aws.StringValue(name.GivenName),
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/mockapi"
+)
+
+func testUserResponse(displayName string, emails ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"DisplayName":     displayName,
+		"Emails":          emails,
+		"IdentityStoreId": "d-1111111111",
+		"Name":            map[string]interface{}{"FamilyName": "Doe", "GivenName": "Jane"},
+		"UserId":          "11111111-1111-1111-1111-111111111111",
+		"UserName":        "jane",
+	}
+}
+
+func testUserConfig(displayName string, emails ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"display_name":      displayName,
+		"emails":            emails,
+		"identity_store_id": "d-1111111111",
+		"name": []interface{}{
+			map[string]interface{}{"family_name": "Doe", "given_name": "Jane"},
+		},
+		"user_name": "jane",
+	}
+}
+
+func TestResourceAwsSsoUser_lifecycle(t *testing.T) {
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"CreateUser": {{Body: map[string]interface{}{
+			"IdentityStoreId": "d-1111111111",
+			"UserId":          "11111111-1111-1111-1111-111111111111",
+		}}},
+		"DescribeUser": {
+			{Body: testUserResponse("Jane Doe")},
+			{Body: testUserResponse("Jane Q. Doe")},
+		},
+		"UpdateUser": {{}},
+		"DeleteUser": {{}},
+	})
+
+	r := resourceAwsSsoUser()
+	state := testResourceApply(t, r, nil, testUserConfig("Jane Doe"), client)
+
+	if got, expected := state.ID, "11111111-1111-1111-1111-111111111111,d-1111111111"; got != expected {
+		t.Fatalf("got ID %s, expected %s", got, expected)
+	}
+
+	creates := api.Requests("CreateUser")
+
+	if got, expected := len(creates), 1; got != expected {
+		t.Fatalf("got %d CreateUser calls, expected %d", got, expected)
+	}
+
+	if got, expected := creates[0].Body["Name"], map[string]interface{}{"FamilyName": "Doe", "GivenName": "Jane"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got Name %v, expected %v", got, expected)
+	}
+
+	state = testResourceApply(t, r, state, testUserConfig("Jane Q. Doe"), client)
+
+	if got, expected := state.Attributes["display_name"], "Jane Q. Doe"; got != expected {
+		t.Errorf("got display_name %s, expected %s", got, expected)
+	}
+
+	updates := api.Requests("UpdateUser")
+
+	if got, expected := len(updates), 1; got != expected {
+		t.Fatalf("got %d UpdateUser calls, expected %d", got, expected)
+	}
+
+	expectedOperations := []interface{}{
+		map[string]interface{}{"AttributePath": "displayName", "AttributeValue": "Jane Q. Doe"},
+	}
+
+	if got := updates[0].Body["Operations"]; !reflect.DeepEqual(got, expectedOperations) {
+		t.Errorf("got operations %v, expected %v", got, expectedOperations)
+	}
+
+	testResourceDestroy(t, r, state, client)
+
+	if got, expected := len(api.Requests("DeleteUser")), 1; got != expected {
+		t.Errorf("got %d DeleteUser calls, expected %d", got, expected)
+	}
+}
+
+func TestResourceAwsSsoUser_addSecondaryEmail(t *testing.T) {
+	primary := map[string]interface{}{"Primary": true, "Type": "work", "Value": "jane@example.com"}
+	secondary := map[string]interface{}{"Primary": false, "Value": "jane.doe@example.com"}
+
+	client, api := testMockClient(t, map[string][]mockapi.Response{
+		"DescribeUser": {{Body: testUserResponse("Jane Doe", primary, secondary)}},
+		"UpdateUser":   {{}},
+	})
+
+	r := resourceAwsSsoUser()
+	state := &terraform.InstanceState{
+		ID: "11111111-1111-1111-1111-111111111111,d-1111111111",
+		Attributes: map[string]string{
+			"display_name":       "Jane Doe",
+			"emails.#":           "1",
+			"emails.0.primary":   "true",
+			"emails.0.type":      "work",
+			"emails.0.value":     "jane@example.com",
+			"identity_store_id":  "d-1111111111",
+			"name.#":             "1",
+			"name.0.family_name": "Doe",
+			"name.0.given_name":  "Jane",
+			"user_id":            "11111111-1111-1111-1111-111111111111",
+			"user_name":          "jane",
+		},
+	}
+
+	state = testResourceApply(t, r, state, testUserConfig("Jane Doe",
+		map[string]interface{}{"type": "work", "value": "jane@example.com"},
+		map[string]interface{}{"value": "jane.doe@example.com"},
+	), client)
+
+	if got, expected := state.Attributes["emails.#"], "2"; got != expected {
+		t.Errorf("got %s emails, expected %s", got, expected)
+	}
+
+	updates := api.Requests("UpdateUser")
+
+	if got, expected := len(updates), 1; got != expected {
+		t.Fatalf("got %d UpdateUser calls, expected %d", got, expected)
+	}
+
+	expectedOperations := []interface{}{
+		map[string]interface{}{"AttributePath": "emails", "AttributeValue": []interface{}{primary, secondary}},
+	}
+
+	if got := updates[0].Body["Operations"]; !reflect.DeepEqual(got, expectedOperations) {
+		t.Errorf("got operations %v, expected %v", got, expectedOperations)
+	}
+}
@@ -0,0 +1,363 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	awsbase "github.com/hashicorp/aws-sdk-go-base"
+	"github.com/hashicorp/aws-sdk-go-base/tfawserr"
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+const (
+	ec2MetadataServiceEndpointModeIPv4 = "IPv4"
+	ec2MetadataServiceEndpointModeIPv6 = "IPv6"
+)
+
+const (
+	stsRegionalEndpointLegacy   = "legacy"
+	stsRegionalEndpointRegional = "regional"
+)
+
+const (
+	retryModeAdaptive = "adaptive"
+	retryModeStandard = "standard"
+)
+
+// Throttling delays used by the adaptive retry mode. The v1 SDK has no native
+// adaptive retry mode, so it is approximated by backing off harder than the
+// default retryer when requests are throttled.
+const (
+	adaptiveRetryMinThrottleDelay = 2 * time.Second
+	adaptiveRetryMaxThrottleDelay = 5 * time.Minute
+)
+
+// sessionOptions mirrors awsbase.GetSessionOptions, additionally applying the
+// provider settings that awsbase does not support.
+func (c *Config) sessionOptions(ctx context.Context, awsbaseConfig *awsbase.Config) (*session.Options, error) {
+	options := &session.Options{
+		Config: aws.Config{
+			EndpointResolver: awsbaseConfig.EndpointResolver(),
+			MaxRetries:       aws.Int(0),
+			Region:           aws.String(awsbaseConfig.Region),
+		},
+		Profile:           awsbaseConfig.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	}
+
+	if err := c.applySharedSessionOptions(options); err != nil {
+		return nil, err
+	}
+
+	if err := c.applyHTTPClientOptions(options, awsbaseConfig.Insecure); err != nil {
+		return nil, err
+	}
+
+	creds, err := c.getCredentials(ctx, awsbaseConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	options.Config.Credentials = creds
+
+	if awsbaseConfig.DebugLogging {
+		options.Config.LogLevel = aws.LogLevel(aws.LogDebugWithHTTPBody | aws.LogDebugWithRequestRetries | aws.LogDebugWithRequestErrors)
+		options.Config.Logger = awsbase.DebugLogger{}
+	}
+
+	if c.RetryMode == retryModeAdaptive {
+		request.WithRetryer(&options.Config, client.DefaultRetryer{
+			NumMaxRetries:    c.MaxRetries,
+			MinThrottleDelay: adaptiveRetryMinThrottleDelay,
+			MaxThrottleDelay: adaptiveRetryMaxThrottleDelay,
+		})
+	}
+
+	return options, nil
+}
+
+// applySharedSessionOptions applies the settings shared by the provider
+// session and the session used to derive credentials.
+func (c *Config) applySharedSessionOptions(options *session.Options) error {
+	sharedConfigFiles, err := c.sharedConfigFiles()
+
+	if err != nil {
+		return err
+	}
+
+	options.SharedConfigFiles = sharedConfigFiles
+	options.EC2IMDSEndpoint = c.EC2MetadataServiceEndpoint
+
+	stsRegionalEndpoint, err := c.stsRegionalEndpoint()
+
+	if err != nil {
+		return err
+	}
+
+	options.Config.STSRegionalEndpoint = stsRegionalEndpoint
+	options.Config.UseDualStackEndpoint = c.dualStackEndpointState()
+	options.Config.UseFIPSEndpoint = c.fipsEndpointState()
+
+	if c.EC2MetadataServiceEndpointMode != "" {
+		if err := options.EC2IMDSEndpointMode.SetFromString(c.EC2MetadataServiceEndpointMode); err != nil {
+			return fmt.Errorf("error setting EC2 metadata service endpoint mode: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// applyHTTPClientOptions sets the HTTP client used by the provider session
+// and the sessions used to obtain credentials, so that requests for
+// credentials use the same proxy and TLS settings as the service clients.
+func (c *Config) applyHTTPClientOptions(options *session.Options, insecure bool) error {
+	options.Config.HTTPClient = cleanhttp.DefaultClient()
+
+	if insecure {
+		transport := options.Config.HTTPClient.Transport.(*http.Transport)
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	if c.HTTPProxy != "" {
+		proxyURL, err := url.Parse(c.HTTPProxy)
+
+		if err != nil {
+			return fmt.Errorf("error parsing HTTP proxy URL (%s): %w", c.HTTPProxy, err)
+		}
+
+		// Without an explicit proxy the transport keeps using the
+		// HTTP_PROXY/HTTPS_PROXY environment variables.
+		transport := options.Config.HTTPClient.Transport.(*http.Transport)
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if c.CustomCABundle != "" {
+		bundle, err := readCABundle(c.CustomCABundle)
+
+		if err != nil {
+			return err
+		}
+
+		// The SDK installs the bundle into the existing TLS configuration,
+		// so Insecure continues to skip verification.
+		options.CustomCABundle = bytes.NewReader(bundle)
+	}
+
+	return nil
+}
+
+// stsRegionalEndpoint returns the STS endpoint resolution mode, leaving it
+// unset when not configured so that the environment and shared config apply.
+func (c *Config) stsRegionalEndpoint() (endpoints.STSRegionalEndpoint, error) {
+	if c.STSRegionalEndpoint == "" {
+		return endpoints.UnsetSTSEndpoint, nil
+	}
+
+	v, err := endpoints.GetSTSRegionalEndpoint(c.STSRegionalEndpoint)
+
+	if err != nil {
+		return endpoints.UnsetSTSEndpoint, fmt.Errorf("error setting STS regional endpoint: %w", err)
+	}
+
+	return v, nil
+}
+
+// dualStackEndpointState returns the dualstack endpoint resolution state,
+// leaving it unset when not enabled so that the environment and shared config
+// apply. It composes with the FIPS endpoint state.
+func (c *Config) dualStackEndpointState() endpoints.DualStackEndpointState {
+	if c.UseDualStackEndpoint {
+		return endpoints.DualStackEndpointStateEnabled
+	}
+
+	return endpoints.DualStackEndpointStateUnset
+}
+
+// fipsEndpointState returns the FIPS endpoint resolution state, leaving it
+// unset when not enabled so that the environment and shared config apply.
+// Explicitly configured endpoints are used as is.
+func (c *Config) fipsEndpointState() endpoints.FIPSEndpointState {
+	if c.UseFIPSEndpoint {
+		return endpoints.FIPSEndpointStateEnabled
+	}
+
+	return endpoints.FIPSEndpointStateUnset
+}
+
+// readCABundle returns the contents of the PEM encoded certificate bundle at
+// path, erroring if it contains no certificates.
+func readCABundle(path string) ([]byte, error) {
+	bundle, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading custom CA bundle (%s): %w", path, err)
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("error loading custom CA bundle (%s): no valid PEM certificates found", path)
+	}
+
+	return bundle, nil
+}
+
+// getSession mirrors awsbase.GetSession, building the session from
+// sessionOptions instead of the awsbase defaults.
+func (c *Config) getSession(ctx context.Context, awsbaseConfig *awsbase.Config) (*session.Session, error) {
+	if awsbaseConfig.SkipMetadataApiCheck {
+		os.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	}
+
+	options, err := c.sessionOptions(ctx, awsbaseConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := session.NewSessionWithOptions(*options)
+
+	if err != nil {
+		if tfawserr.ErrCodeEquals(err, "NoCredentialProviders") {
+			return nil, awsbaseConfig.NewNoValidCredentialSourcesError(err)
+		}
+		return nil, fmt.Errorf("Error creating AWS session: %w", err)
+	}
+
+	if awsbaseConfig.MaxRetries > 0 {
+		sess = sess.Copy(&aws.Config{MaxRetries: aws.Int(awsbaseConfig.MaxRetries)})
+	}
+
+	// The configured User-Agent products take precedence over the SDK product,
+	// so they are pushed to the front in reverse to keep their order.
+	for i := len(awsbaseConfig.UserAgentProducts) - 1; i >= 0; i-- {
+		product := awsbaseConfig.UserAgentProducts[i]
+		sess.Handlers.Build.PushFront(request.MakeAddToUserAgentHandler(product.Name, product.Version, product.Extra...))
+	}
+
+	if c.UserAgentExtra != "" {
+		sess.Handlers.Build.PushBack(request.MakeAddToUserAgentFreeFormHandler(c.UserAgentExtra))
+	}
+
+	if v := os.Getenv(awsbase.AppendUserAgentEnvVar); v != "" {
+		log.Printf("[DEBUG] Using additional User-Agent Info: %s", v)
+		sess.Handlers.Build.PushBack(request.MakeAddToUserAgentFreeFormHandler(v))
+	}
+
+	// Stop retrying permanent networking failures, such as a non-existent
+	// service endpoint, which the high default retry count would otherwise mask.
+	sess.Handlers.Retry.PushBack(func(r *request.Request) {
+		if r.RetryCount < awsbase.MaxNetworkRetryCount {
+			return
+		}
+		if tfawserr.ErrMessageAndOrigErrContain(r.Error, "RequestError", "send request failed", "no such host") {
+			log.Printf("[WARN] Disabling retries after next request due to networking issue")
+			r.Retryable = aws.Bool(false)
+		}
+		if tfawserr.ErrMessageAndOrigErrContain(r.Error, "RequestError", "send request failed", "connection refused") {
+			log.Printf("[WARN] Disabling retries after next request due to networking issue")
+			r.Retryable = aws.Bool(false)
+		}
+	})
+
+	return sess, nil
+}
+
+// getSessionWithAccountIDAndPartition mirrors
+// awsbase.GetSessionWithAccountIDAndPartition using getSession.
+func (c *Config) getSessionWithAccountIDAndPartition(ctx context.Context, awsbaseConfig *awsbase.Config) (*session.Session, string, string, error) {
+	sess, err := c.getSession(ctx, awsbaseConfig)
+
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	assumeRoleChain, err := c.assumeRoleChain()
+
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	var roleARN string
+
+	// The provider operates as the last role assumed.
+	if len(assumeRoleChain) > 0 {
+		roleARN = assumeRoleChain[len(assumeRoleChain)-1].RoleARN
+	}
+
+	if c.AssumeRoleWithWebIdentity != nil {
+		roleARN = c.AssumeRoleWithWebIdentity.RoleARN
+	}
+
+	if roleARN != "" {
+		if !awsbaseConfig.SkipCredsValidation {
+			if _, _, err := awsbase.GetAccountIDAndPartitionFromSTSGetCallerIdentity(sts.New(sess)); err != nil {
+				return nil, "", "", fmt.Errorf("error validating provider credentials: %w", err)
+			}
+		}
+
+		var accountID, partition string
+		if v, err := arn.Parse(roleARN); err == nil {
+			accountID, partition = v.AccountID, v.Partition
+		}
+
+		return sess, accountID, partition, nil
+	}
+
+	iamClient := iam.New(sess)
+	stsClient := sts.New(sess)
+
+	if !awsbaseConfig.SkipCredsValidation {
+		accountID, partition, err := awsbase.GetAccountIDAndPartitionFromSTSGetCallerIdentity(stsClient)
+
+		if err != nil {
+			return nil, "", "", fmt.Errorf("error validating provider credentials: %w", err)
+		}
+
+		return sess, accountID, partition, nil
+	}
+
+	if !awsbaseConfig.SkipRequestingAccountId {
+		credentialsProviderName := ""
+
+		if credentialsValue, err := sess.Config.Credentials.GetWithContext(ctx); err == nil {
+			credentialsProviderName = credentialsValue.ProviderName
+		}
+
+		accountID, partition, err := awsbase.GetAccountIDAndPartition(iamClient, stsClient, credentialsProviderName)
+
+		if err == nil {
+			return sess, accountID, partition, nil
+		}
+
+		return nil, "", "", fmt.Errorf(
+			"AWS account ID not previously found and failed retrieving via all available methods. "+
+				"See https://www.terraform.io/docs/providers/aws/index.html#skip_requesting_account_id for workaround and implications. "+
+				"Errors: %w", err)
+	}
+
+	var partition string
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), awsbaseConfig.Region); ok {
+		partition = p.ID()
+	}
+
+	return sess, "", partition, nil
+}
@@ -1,9 +1,36 @@
 package aws
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+)
+
+const (
+	tagKeyMaxLength   = 128
+	tagValueMaxLength = 256
 )
 
+var tagRegexp = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+
+// tagsSchema returns the schema to use for tags.
+func tagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeMap,
+		Optional:         true,
+		Elem:             &schema.Schema{Type: schema.TypeString},
+		ValidateDiagFunc: validateTags,
+	}
+}
+
 func tagsSchemaComputed() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeMap,
@@ -12,3 +39,113 @@ func tagsSchemaComputed() *schema.Schema {
 		Elem:     &schema.Schema{Type: schema.TypeString},
 	}
 }
+
+// validateTags checks tag keys and values against the AWS tagging constraints,
+// including the aws: prefix that is reserved for tags applied by AWS.
+func validateTags(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tags := v.(map[string]interface{})
+	keys := make([]string, 0, len(tags))
+
+	for key := range tags {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := path.IndexString(key)
+
+		if n := utf8.RuneCountInString(key); n < 1 || n > tagKeyMaxLength {
+			diags = append(diags, tagDiagnostic(keyPath, "tag key %q must be between 1 and %d characters", key, tagKeyMaxLength))
+		}
+
+		if !tagRegexp.MatchString(key) {
+			diags = append(diags, tagDiagnostic(keyPath, "tag key %q must only contain letters, numbers, spaces and _.:/=+-@", key))
+		}
+
+		if strings.HasPrefix(strings.ToLower(key), "aws:") {
+			diags = append(diags, tagDiagnostic(keyPath, "tag key %q must not use the reserved aws: prefix", key))
+		}
+
+		value, _ := tags[key].(string)
+
+		if n := utf8.RuneCountInString(value); n > tagValueMaxLength {
+			diags = append(diags, tagDiagnostic(keyPath, "value of tag %q must be at most %d characters", key, tagValueMaxLength))
+		}
+
+		if !tagRegexp.MatchString(value) {
+			diags = append(diags, tagDiagnostic(keyPath, "value of tag %q must only contain letters, numbers, spaces and _.:/=+-@", key))
+		}
+	}
+
+	return diags
+}
+
+func tagDiagnostic(path cty.Path, format string, a ...interface{}) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       "Invalid tag",
+		Detail:        fmt.Sprintf(format, a...),
+		AttributePath: path,
+	}
+}
+
+// mergeTags returns the provider default_tags merged with the resource tags.
+// Resource tags take precedence, so a resource tag overrides the value of a
+// default tag with the same key.
+func mergeTags(client *AWSClient, resourceTags keyvaluetags.KeyValueTags) keyvaluetags.KeyValueTags {
+	return client.DefaultTagsConfig.MergeTags(resourceTags)
+}
+
+// ignoreTags returns the tags without those matching the provider ignore_tags
+// configuration, either by exact key or by key prefix. Both comparisons are
+// case-sensitive and prefixes only match from the start of the key.
+func ignoreTags(client *AWSClient, tags keyvaluetags.KeyValueTags) keyvaluetags.KeyValueTags {
+	return tags.IgnoreConfig(client.IgnoreTagsConfig)
+}
+
+// setTagsComputed sets the computed tags attribute key, first removing
+// AWS reserved tags and any tags ignored by the provider configuration.
+func setTagsComputed(d *schema.ResourceData, key string, tags keyvaluetags.KeyValueTags, meta interface{}) error {
+	if err := d.Set(key, ignoreTags(meta.(*AWSClient), tags.IgnoreAws()).Map()); err != nil {
+		return fmt.Errorf("error setting %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// SetTagsDiff sets the new plan difference with the result of
+// merging resource tags on to those defined at the provider-level;
+// returns an error if unsuccessful or if the resource tags are identical
+// to those configured at the provider-level to avoid non-empty plans
+// after resource READ operations as resource and provider-level tags
+// will be indistinguishable when returned from an AWS API.
+func SetTagsDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
+
+	resourceTags := keyvaluetags.New(diff.Get("tags").(map[string]interface{}))
+
+	if defaultTagsConfig.TagsEqual(resourceTags) {
+		return fmt.Errorf(`"tags" are identical to those in the "default_tags" configuration block of the provider: please de-duplicate and try again`)
+	}
+
+	allTags := ignoreTags(meta.(*AWSClient), mergeTags(meta.(*AWSClient), resourceTags))
+
+	// To ensure "tags_all" is correctly computed, we explicitly set the attribute diff
+	// when the merger of resource-level tags onto provider-level tags results in n > 0 tags,
+	// otherwise we mark the attribute as "Computed" only when there is a known diff (excluding an empty map)
+	// or a change for "tags_all".
+	if len(allTags) > 0 {
+		if err := diff.SetNew("tags_all", allTags.Map()); err != nil {
+			return fmt.Errorf("error setting new tags_all diff: %w", err)
+		}
+	} else if len(diff.Get("tags_all").(map[string]interface{})) > 0 {
+		if err := diff.SetNewComputed("tags_all"); err != nil {
+			return fmt.Errorf("error setting tags_all to computed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package aws
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/takescoop/terraform-provider-awssso/awssso/internal/keyvaluetags"
+)
+
+func TestSetTagsComputed(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"tags_all": tagsSchemaComputed(),
+	}, map[string]interface{}{})
+
+	client := &AWSClient{
+		IgnoreTagsConfig: &keyvaluetags.IgnoreConfig{
+			Keys:        keyvaluetags.New([]interface{}{"ignored"}),
+			KeyPrefixes: keyvaluetags.New([]interface{}{"ignored:"}),
+		},
+	}
+
+	tags := keyvaluetags.New(map[string]interface{}{
+		"aws:cloudformation:stack-name": "stack",
+		"ignored":                       "value",
+		"ignored:prefix":                "value",
+		"kept":                          "value",
+	})
+
+	if err := setTagsComputed(d, "tags_all", tags, client); err != nil {
+		t.Fatalf("error setting tags: %s", err)
+	}
+
+	got := d.Get("tags_all").(map[string]interface{})
+
+	for _, key := range []string{"aws:cloudformation:stack-name", "ignored", "ignored:prefix"} {
+		if _, ok := got[key]; ok {
+			t.Errorf("expected tag %q to be ignored, got: %v", key, got)
+		}
+	}
+
+	if got["kept"] != "value" {
+		t.Errorf("expected tag %q to be kept, got: %v", "kept", got)
+	}
+}
+
+func TestIgnoreTags(t *testing.T) {
+	client := &AWSClient{
+		IgnoreTagsConfig: &keyvaluetags.IgnoreConfig{
+			Keys:        keyvaluetags.New([]interface{}{"exact"}),
+			KeyPrefixes: keyvaluetags.New([]interface{}{"prefix:"}),
+		},
+	}
+
+	testCases := []struct {
+		TestName        string
+		Key             string
+		ExpectedIgnored bool
+	}{
+		{
+			TestName:        "exact key",
+			Key:             "exact",
+			ExpectedIgnored: true,
+		},
+		{
+			TestName:        "prefix match",
+			Key:             "prefix:team",
+			ExpectedIgnored: true,
+		},
+		{
+			TestName: "non-match",
+			Key:      "other",
+		},
+		{
+			TestName: "key containing exact key",
+			Key:      "exact-match",
+		},
+		{
+			TestName: "prefix case mismatch",
+			Key:      "Prefix:team",
+		},
+		{
+			TestName: "prefix not at start",
+			Key:      "team:prefix:",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			got := ignoreTags(client, keyvaluetags.New(map[string]interface{}{testCase.Key: "value"}))
+
+			if _, ok := got[testCase.Key]; ok == testCase.ExpectedIgnored {
+				t.Errorf("got ignored %t, expected %t", !ok, testCase.ExpectedIgnored)
+			}
+		})
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	testCases := []struct {
+		TestName     string
+		DefaultTags  map[string]interface{}
+		ResourceTags map[string]interface{}
+		Expected     map[string]string
+	}{
+		{
+			TestName:     "resource tags override defaults",
+			DefaultTags:  map[string]interface{}{"env": "default", "team": "platform"},
+			ResourceTags: map[string]interface{}{"env": "prod"},
+			Expected:     map[string]string{"env": "prod", "team": "platform"},
+		},
+		{
+			TestName:     "only defaults",
+			DefaultTags:  map[string]interface{}{"team": "platform"},
+			ResourceTags: map[string]interface{}{},
+			Expected:     map[string]string{"team": "platform"},
+		},
+		{
+			TestName:     "no defaults",
+			ResourceTags: map[string]interface{}{"env": "prod"},
+			Expected:     map[string]string{"env": "prod"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			client := &AWSClient{}
+
+			if testCase.DefaultTags != nil {
+				client.DefaultTagsConfig = &keyvaluetags.DefaultConfig{
+					Tags: keyvaluetags.New(testCase.DefaultTags),
+				}
+			}
+
+			got := mergeTags(client, keyvaluetags.New(testCase.ResourceTags)).Map()
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Tags          map[string]interface{}
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName: "valid",
+			Tags: map[string]interface{}{
+				"Team":              "Platform",
+				"cost-center/owner": "jane@example.com",
+				"Empty":             "",
+			},
+		},
+		{
+			TestName: "key too long",
+			Tags: map[string]interface{}{
+				strings.Repeat("k", 129): "value",
+			},
+			ExpectedError: regexp.MustCompile(`must be between 1 and 128 characters`),
+		},
+		{
+			TestName: "maximum key length",
+			Tags: map[string]interface{}{
+				strings.Repeat("k", 128): "value",
+			},
+		},
+		{
+			TestName: "value too long",
+			Tags: map[string]interface{}{
+				"Team": strings.Repeat("v", 257),
+			},
+			ExpectedError: regexp.MustCompile(`value of tag "Team" must be at most 256 characters`),
+		},
+		{
+			TestName: "maximum value length",
+			Tags: map[string]interface{}{
+				"Team": strings.Repeat("v", 256),
+			},
+		},
+		{
+			TestName: "reserved prefix",
+			Tags: map[string]interface{}{
+				"aws:cloudformation:stack-name": "stack",
+			},
+			ExpectedError: regexp.MustCompile(`must not use the reserved aws: prefix`),
+		},
+		{
+			TestName: "reserved prefix uppercase",
+			Tags: map[string]interface{}{
+				"AWS:Team": "Platform",
+			},
+			ExpectedError: regexp.MustCompile(`must not use the reserved aws: prefix`),
+		},
+		{
+			TestName: "invalid characters",
+			Tags: map[string]interface{}{
+				"Team": "a;b",
+			},
+			ExpectedError: regexp.MustCompile(`value of tag "Team" must only contain`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			diags := validateTags(testCase.Tags, cty.GetAttrPath("tags"))
+
+			if !diags.HasError() && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if diags.HasError() && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected errors: %v", diags)
+			}
+
+			if diags.HasError() && !testCase.ExpectedError.MatchString(diags[0].Detail) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), diags[0].Detail)
+			}
+		})
+	}
+}
@@ -2,9 +2,15 @@ package aws
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -23,6 +29,19 @@ var awsAccountIDRegexp = regexp.MustCompile(awsAccountIDRegexpPattern)
 var awsPartitionRegexp = regexp.MustCompile(awsPartitionRegexpPattern)
 var awsRegionRegexp = regexp.MustCompile(awsRegionRegexpPattern)
 
+var ssoPrincipalIDRegexp = regexp.MustCompile(`^([0-9a-f]{10}-|)[A-Fa-f0-9]{8}-[A-Fa-f0-9]{4}-[A-Fa-f0-9]{4}-[A-Fa-f0-9]{4}-[A-Fa-f0-9]{12}$`)
+
+var iso8601DurationRegexp = regexp.MustCompile(`^PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?$`)
+
+const (
+	ssoSessionDurationMin = 1 * time.Hour
+	ssoSessionDurationMax = 12 * time.Hour
+)
+
+// ssoInlinePolicyMaxLength is the maximum number of characters ssoadmin
+// accepts for a permission set inline policy.
+const ssoInlinePolicyMaxLength = 32768
+
 func validateArn(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 
@@ -57,3 +76,129 @@ func validateArn(v interface{}, k string) (ws []string, errors []error) {
 
 	return ws, errors
 }
+
+// validateIamPolicyArn validates that the value is a well-formed IAM policy ARN
+// in any partition, e.g. arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess.
+func validateIamPolicyArn(v interface{}, k string) (ws []string, errors []error) {
+	ws, errors = validateArn(v, k)
+
+	if len(errors) > 0 {
+		return ws, errors
+	}
+
+	value := v.(string)
+
+	if value == "" {
+		return ws, errors
+	}
+
+	parsedARN, _ := arn.Parse(value)
+
+	if parsedARN.Service != "iam" || !strings.HasPrefix(parsedARN.Resource, "policy/") {
+		errors = append(errors, fmt.Errorf("%q (%s) is an invalid IAM policy ARN: expected iam service and policy/ resource", k, value))
+	}
+
+	return ws, errors
+}
+
+// validateAbsoluteURL validates that the value is an absolute URL with a
+// scheme and host, such as an AWS console deep link.
+func validateAbsoluteURL(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	u, err := url.Parse(value)
+
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q (%s) is an invalid URL: %s", k, value, err))
+		return ws, errors
+	}
+
+	if !u.IsAbs() || u.Host == "" {
+		errors = append(errors, fmt.Errorf("%q (%s) is an invalid URL: expected an absolute URL with a scheme and host", k, value))
+	}
+
+	return ws, errors
+}
+
+// validateSsoPrincipalId checks that the value is an Identity Store user or
+// group ID, optionally prefixed with the identity store ID.
+var validateSsoPrincipalId = validation.All(
+	validation.StringLenBetween(1, 47),
+	validation.StringMatch(ssoPrincipalIDRegexp, "must match ([0-9a-f]{10}-|)[A-Fa-f0-9]{8}-[A-Fa-f0-9]{4}-[A-Fa-f0-9]{4}-[A-Fa-f0-9]{4}-[A-Fa-f0-9]{12}"),
+)
+
+func validateAwsAccountId(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if !regexp.MustCompile(`^\d{12}$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q doesn't look like AWS Account ID (exactly 12 digits): %q", k, value))
+	}
+
+	return ws, errors
+}
+
+// parseIso8601Duration parses the time portion of an ISO-8601 duration, such
+// as PT1H30M, which is the only form accepted by the SSO Admin API.
+func parseIso8601Duration(value string) (time.Duration, error) {
+	matches := iso8601DurationRegexp.FindStringSubmatch(value)
+
+	if matches == nil || value == "PT" {
+		return 0, fmt.Errorf("invalid ISO-8601 duration %q, expected a format such as PT1H30M", value)
+	}
+
+	var duration time.Duration
+
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if matches[i+1] == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(matches[i+1])
+
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO-8601 duration %q: %w", value, err)
+		}
+
+		duration += time.Duration(n) * unit
+	}
+
+	return duration, nil
+}
+
+func validateSsoSessionDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	duration, err := parseIso8601Duration(value)
+
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+		return ws, errors
+	}
+
+	if duration < ssoSessionDurationMin || duration > ssoSessionDurationMax {
+		errors = append(errors, fmt.Errorf("%q (%s) must be between PT1H and PT12H", k, value))
+	}
+
+	return ws, errors
+}
+
+// validateSsoInlinePolicy checks that the value is a single JSON document whose
+// normalized form fits within the inline policy size limit. The resource sends
+// the normalized form to ssoadmin, so whitespace in the configuration does not
+// count towards the limit.
+func validateSsoInlinePolicy(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	policy, err := normalizePolicyJSON(value)
+
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON policy: %s", k, err))
+		return ws, errors
+	}
+
+	if n := utf8.RuneCountInString(policy); n > ssoInlinePolicyMaxLength {
+		errors = append(errors, fmt.Errorf("%q (%d characters) must be at most %d characters", k, n, ssoInlinePolicyMaxLength))
+	}
+
+	return ws, errors
+}
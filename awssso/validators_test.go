@@ -0,0 +1,315 @@
+package aws
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIso8601Duration(t *testing.T) {
+	testCases := []struct {
+		TestName         string
+		Input            string
+		ExpectedError    *regexp.Regexp
+		ExpectedDuration time.Duration
+	}{
+		{
+			TestName:      "empty",
+			Input:         "",
+			ExpectedError: regexp.MustCompile(`invalid ISO-8601 duration`),
+		},
+		{
+			TestName:      "no components",
+			Input:         "PT",
+			ExpectedError: regexp.MustCompile(`invalid ISO-8601 duration`),
+		},
+		{
+			TestName:      "date component",
+			Input:         "P1D",
+			ExpectedError: regexp.MustCompile(`invalid ISO-8601 duration`),
+		},
+		{
+			TestName:      "lowercase",
+			Input:         "pt1h",
+			ExpectedError: regexp.MustCompile(`invalid ISO-8601 duration`),
+		},
+		{
+			TestName:         "hours",
+			Input:            "PT8H",
+			ExpectedDuration: 8 * time.Hour,
+		},
+		{
+			TestName:         "hours and minutes",
+			Input:            "PT1H30M",
+			ExpectedDuration: 90 * time.Minute,
+		},
+		{
+			TestName:         "seconds",
+			Input:            "PT3600S",
+			ExpectedDuration: time.Hour,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			got, err := parseIso8601Duration(testCase.Input)
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+
+			if got != testCase.ExpectedDuration {
+				t.Errorf("got %s, expected %s", got, testCase.ExpectedDuration)
+			}
+		})
+	}
+}
+
+func TestValidateSsoSessionDuration(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Input         string
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName:      "too short",
+			Input:         "PT30M",
+			ExpectedError: regexp.MustCompile(`must be between PT1H and PT12H`),
+		},
+		{
+			TestName:      "too long",
+			Input:         "PT13H",
+			ExpectedError: regexp.MustCompile(`must be between PT1H and PT12H`),
+		},
+		{
+			TestName:      "malformed",
+			Input:         "8 hours",
+			ExpectedError: regexp.MustCompile(`invalid ISO-8601 duration`),
+		},
+		{
+			TestName: "valid",
+			Input:    "PT8H",
+		},
+		{
+			TestName: "minimum",
+			Input:    "PT1H",
+		},
+		{
+			TestName: "maximum",
+			Input:    "PT12H",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			_, errors := validateSsoSessionDuration(testCase.Input, "session_duration")
+
+			if len(errors) == 0 && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if len(errors) > 0 && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected errors: %v", errors)
+			}
+
+			if len(errors) > 0 && !testCase.ExpectedError.MatchString(errors[0].Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), errors[0])
+			}
+		})
+	}
+}
+
+func TestValidateIamPolicyArn(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Input         string
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName: "managed policy",
+			Input:    "arn:aws:iam::aws:policy/ReadOnlyAccess",
+		},
+		{
+			TestName: "customer managed policy",
+			Input:    "arn:aws:iam::123456789012:policy/path/Custom",
+		},
+		{
+			TestName: "GovCloud partition",
+			Input:    "arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess",
+		},
+		{
+			TestName: "China partition",
+			Input:    "arn:aws-cn:iam::aws:policy/ReadOnlyAccess",
+		},
+		{
+			TestName:      "not an ARN",
+			Input:         "ReadOnlyAccess",
+			ExpectedError: regexp.MustCompile(`is an invalid ARN`),
+		},
+		{
+			TestName:      "role ARN",
+			Input:         "arn:aws:iam::123456789012:role/Admin",
+			ExpectedError: regexp.MustCompile(`is an invalid IAM policy ARN`),
+		},
+		{
+			TestName:      "invalid partition",
+			Input:         "arn:gcp:iam::aws:policy/ReadOnlyAccess",
+			ExpectedError: regexp.MustCompile(`invalid partition value`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			_, errors := validateIamPolicyArn(testCase.Input, "policy_arns")
+
+			if len(errors) == 0 && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if len(errors) > 0 && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected errors: %v", errors)
+			}
+
+			if len(errors) > 0 && !testCase.ExpectedError.MatchString(errors[0].Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), errors[0])
+			}
+		})
+	}
+}
+
+func TestValidateAbsoluteURL(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Input         string
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName: "https URL",
+			Input:    "https://console.aws.amazon.com/ec2/v2/home?region=us-east-1#Instances:",
+		},
+		{
+			TestName:      "not a URL",
+			Input:         "not a url",
+			ExpectedError: regexp.MustCompile(`expected an absolute URL`),
+		},
+		{
+			TestName:      "relative path",
+			Input:         "/ec2/v2/home",
+			ExpectedError: regexp.MustCompile(`expected an absolute URL`),
+		},
+		{
+			TestName:      "missing scheme",
+			Input:         "console.aws.amazon.com/ec2",
+			ExpectedError: regexp.MustCompile(`expected an absolute URL`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			_, errors := validateAbsoluteURL(testCase.Input, "relay_state")
+
+			if len(errors) == 0 && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if len(errors) > 0 && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected errors: %v", errors)
+			}
+
+			if len(errors) > 0 && !testCase.ExpectedError.MatchString(errors[0].Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), errors[0])
+			}
+		})
+	}
+}
+
+func TestValidateSsoInlinePolicy(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Input         string
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			TestName: "valid",
+			Input:    `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:ListBucket", "Resource": "*"}]}`,
+		},
+		{
+			TestName:      "invalid JSON",
+			Input:         `{"Version": "2012-10-17",`,
+			ExpectedError: regexp.MustCompile(`contains an invalid JSON policy`),
+		},
+		{
+			TestName:      "trailing data",
+			Input:         `{"Version": "2012-10-17"} {}`,
+			ExpectedError: regexp.MustCompile(`contains an invalid JSON policy: unexpected data after policy document`),
+		},
+		{
+			TestName:      "too long",
+			Input:         `{"Sid":"` + strings.Repeat("a", ssoInlinePolicyMaxLength) + `"}`,
+			ExpectedError: regexp.MustCompile(`must be at most 32768 characters`),
+		},
+		{
+			TestName: "long before normalization",
+			Input:    `{"Sid": "a"` + strings.Repeat(" ", ssoInlinePolicyMaxLength) + `}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			_, errors := validateSsoInlinePolicy(testCase.Input, "inline_policy")
+
+			if len(errors) == 0 && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if len(errors) > 0 && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected errors: %v", errors)
+			}
+
+			if len(errors) > 0 && !testCase.ExpectedError.MatchString(errors[0].Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), errors[0])
+			}
+		})
+	}
+}
+
+func TestValidateSsoPrincipalId(t *testing.T) {
+	testCases := []struct {
+		TestName      string
+		Input         string
+		ExpectedError bool
+	}{
+		{
+			TestName: "ID",
+			Input:    "11111111-1111-1111-1111-111111111111",
+		},
+		{
+			TestName: "identity store prefixed ID",
+			Input:    "1111111111-11111111-1111-1111-1111-111111111111",
+		},
+		{
+			TestName:      "invalid",
+			Input:         "user-1",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.TestName, func(t *testing.T) {
+			_, errors := validateSsoPrincipalId(testCase.Input, "principal_id")
+
+			if got := len(errors) > 0; got != testCase.ExpectedError {
+				t.Errorf("got error %t, expected %t: %v", got, testCase.ExpectedError, errors)
+			}
+		})
+	}
+}
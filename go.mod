@@ -3,10 +3,13 @@ module github.com/takescoop/terraform-provider-awssso
 go 1.15
 
 require (
-	github.com/aws/aws-sdk-go v1.38.31
+	github.com/aws/aws-sdk-go v1.55.8 // customer managed policy reference APIs need v1.44.57 or later
 	github.com/hashicorp/aws-sdk-go-base v0.7.1
+	github.com/hashicorp/go-cleanhttp v0.5.2
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/go-multierror v1.0.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.6.1
 	github.com/mitchellh/copystructure v1.1.2 // indirect
+	github.com/mitchellh/go-homedir v1.1.0
 	github.com/mitchellh/go-testing-interface v1.14.1
 )